@@ -1,17 +1,13 @@
-// Exercise 1.6: Modify the Lissajous program to produce images in multiple
+// Exercise 1.6: Modify the Lissajous program to produce images in multiple
 // colors by adding more values to palette and then displaying them by changing
 // the third argument of SetColorIndex in some interesting way.
 
 package main
 
 import (
-	"image"
 	"image/color"
-	"image/gif"
-	"io"
-	"math"
-	"math/rand"
-	"os"
+
+	"ch01/lissajous"
 )
 
 // This block defines the color palette and constants for color indices
@@ -23,40 +19,16 @@ var palette = []color.Color{
 	color.RGBA{0x00, 0x00, 0xFF, 0xFF}}
 
 const (
-	whiteIndex = 0
-	blackIndex = 1
+	blackIndex = 0
+	whiteIndex = 1
 	redIndex   = 2
 	greenIndex = 3
 	blueIndex  = 4
 )
 
 func main() {
-	lissajous(os.Stdout)
-}
-
-func lissajous(out io.Writer) {
-	const (
-		cycles  = 5     // number of complete x oscillator revolutions
-		res     = 0.001 // angular resolution
-		size    = 100   // image canvas covers [-size..+size]
-		nframes = 64    // number of frames in the animation
-		delay   = 8     // delay between frames in 10ms units
-	)
-
-	freq := rand.Float64() * 3.0        // relative frequency of y oscillator
-	anim := gif.GIF{LoopCount: nframes} // composite literal for gif.GIF struct
-	phase := 0.0                        // phase difference
-	for i := 0; i < nframes; i++ {
-		rect := image.Rect(0, 0, 2*size+1, 2*size+1)
-		img := image.NewPaletted(rect, palette)
-		for t := 0.0; t < cycles*2*math.Pi; t += res {
-			x := math.Sin(t)
-			y := math.Sin(t*freq + phase)
-			img.SetColorIndex(size+int(x*size+0.5), size+int(y*size+0.5), blueIndex)
-		}
-		phase += 0.1
-		anim.Delay = append(anim.Delay, delay)
-		anim.Image = append(anim.Image, img)
-	}
-	gif.EncodeAll(out, &anim) // Note: using gif.EncodeAll instead of gif.Encode
+	p := lissajous.DefaultParams()
+	p.Palette = palette
+	p.Scheme = lissajous.ByT // cycles white, red, green and blue along the curve
+	lissajous.Main(p)
 }
@@ -0,0 +1,3 @@
+module ch01
+
+go 1.23
@@ -0,0 +1,177 @@
+package lissajous
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A Scheme decides the color of each point of the curve. Apart from Solid,
+// schemes spread the points over all of Palette[1:], so a palette with
+// more colors gives a smoother gradient.
+type Scheme string
+
+const (
+	Solid      Scheme = "solid"    // every point in Params.Index
+	ByT        Scheme = "t"        // by position along the curve
+	ByFrame    Scheme = "frame"    // by frame, so the color cycles over the animation
+	ByVelocity Scheme = "velocity" // by how fast the point is moving
+	Heat       Scheme = "heat"     // by how densely the curve covers each pixel
+)
+
+// Schemes lists every color scheme.
+var Schemes = []Scheme{Solid, ByT, ByFrame, ByVelocity, Heat}
+
+func (s Scheme) valid() bool { return s == "" || slices.Contains(Schemes, s) }
+
+// DefaultPalette returns the palette that suits the scheme: the book's
+// black on white for Solid, HeatPalette for Heat, and RainbowPalette for
+// the others.
+func (s Scheme) DefaultPalette() color.Palette {
+	switch s {
+	case Heat:
+		return HeatPalette(32)
+	case ByT, ByFrame, ByVelocity:
+		return RainbowPalette(32)
+	}
+	return color.Palette{color.White, color.Black}
+}
+
+// RainbowPalette returns a black background followed by n fully saturated
+// hues from red through violet.
+func RainbowPalette(n int) color.Palette {
+	pal := color.Palette{color.Black}
+	for i := 0; i < n; i++ {
+		pal = append(pal, hue(300*float64(i)/float64(n)))
+	}
+	return pal
+}
+
+// HeatPalette returns a black background followed by n colors that run
+// from dark red through red and yellow to white.
+func HeatPalette(n int) color.Palette {
+	pal := color.Palette{color.Black}
+	for i := 0; i < n; i++ {
+		f := 3 * float64(i+1) / float64(n) // 0..1 red, 1..2 green, 2..3 blue
+		ch := func(v float64) uint8 { return uint8(255*math.Max(0, math.Min(1, v)) + 0.5) }
+		pal = append(pal, color.RGBA{ch(f), ch(f - 1), ch(f - 2), 0xFF})
+	}
+	return pal
+}
+
+// hue returns the fully saturated color of hue h in degrees.
+func hue(h float64) color.RGBA {
+	x := 1 - math.Abs(math.Mod(h/60, 2)-1)
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = 1, x
+	case h < 120:
+		r, g = x, 1
+	case h < 180:
+		g, b = 1, x
+	case h < 240:
+		g, b = x, 1
+	case h < 300:
+		r, b = x, 1
+	default:
+		r, b = 1, x
+	}
+	return color.RGBA{uint8(r*255 + 0.5), uint8(g*255 + 0.5), uint8(b*255 + 0.5), 0xFF}
+}
+
+// ParsePalette parses "rainbow", "heat", or a comma-separated list of hex
+// colors such as "000000,ff0000,00ff00". The first color of a list is the
+// background.
+func ParsePalette(s string) (color.Palette, error) {
+	switch s {
+	case "rainbow":
+		return RainbowPalette(32), nil
+	case "heat":
+		return HeatPalette(32), nil
+	}
+	var pal color.Palette
+	for _, h := range strings.Split(s, ",") {
+		h = strings.TrimPrefix(strings.TrimSpace(h), "#")
+		v, err := strconv.ParseUint(h, 16, 32)
+		if err != nil || len(h) != 6 {
+			return nil, fmt.Errorf("palette: %q is not a hex color like ff8000", h)
+		}
+		pal = append(pal, color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xFF})
+	}
+	if len(pal) < 2 || len(pal) > 256 {
+		return nil, fmt.Errorf("palette: has %d colors, want 2 to 256", len(pal))
+	}
+	return pal, nil
+}
+
+// colorer returns a function that gives the index into p.Palette of a
+// point of frame i, given the sample and the one passed with it by
+// eachStep.
+func (p Params) colorer(i int) func(s, next sample) uint8 {
+	n := len(p.Palette) - 1 // colors available to the curve
+	pick := func(f float64) uint8 { return uint8(1 + min(n-1, max(0, int(f*float64(n))))) }
+	switch p.Scheme {
+	case ByT:
+		end := float64(p.Cycles) * 2 * math.Pi
+		return func(s, _ sample) uint8 { return pick(s.t / end) }
+	case ByFrame:
+		k := pick(float64(i%p.NFrames) / float64(p.NFrames))
+		return func(_, _ sample) uint8 { return k }
+	case ByVelocity:
+		// The samples are evenly spaced in t, so the distance to the next
+		// one is proportional to the speed. Finding the fastest takes a
+		// pass over the curve of its own.
+		speed := func(s, next sample) float64 { return math.Hypot(next.x-s.x, next.y-s.y) }
+		fastest := 0.0
+		p.eachStep(i, func(s, next sample, last bool) {
+			if !last {
+				fastest = math.Max(fastest, speed(s, next))
+			}
+		})
+		if fastest == 0 {
+			fastest = 1
+		}
+		return func(s, next sample) uint8 { return pick(speed(s, next) / fastest) }
+	}
+	return func(_, _ sample) uint8 { return p.Index }
+}
+
+// drawHeat colors each pixel of frame i by how much of the curve passes
+// through it, on a logarithmic scale so that sparse pixels still show.
+func drawHeat(img *image.Paletted, p Params, i int) {
+	w := img.Rect.Dx()
+	density := make([]float64, w*img.Rect.Dy())
+	add := func(x, y int, a float64) {
+		if (image.Point{x, y}).In(img.Rect) {
+			density[y*w+x] += a
+		}
+	}
+	size := float64(p.Size)
+	if p.Antialias {
+		p.eachStep(i, func(a, b sample, last bool) {
+			if !last {
+				thickLine(size+a.x*size, size+a.y*size, size+b.x*size, size+b.y*size, p.Thickness, add)
+			}
+		})
+	} else {
+		p.eachSample(i, func(pt sample) {
+			add(p.Size+int(pt.x*size+0.5), p.Size+int(pt.y*size+0.5), 1)
+		})
+	}
+	densest := 0.0
+	for _, d := range density {
+		densest = math.Max(densest, d)
+	}
+	n := len(p.Palette) - 1
+	for j, d := range density {
+		if d > 0 {
+			f := math.Log1p(d) / math.Log1p(densest)
+			img.Pix[j] = uint8(1 + min(n-1, int(f*float64(n))))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package lissajous
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Curve is a family of parametric figures. Point returns the point at t
+// of the member with relative frequency freq, drawn at phase, with both
+// coordinates in [-1, 1]. The animation steps phase from frame to frame, t
+// runs over p.Cycles revolutions, and freq is p.Freq or drawn from p.Seed,
+// so any Curve shares the sampling, coloring and encoding of the others.
+type Curve interface {
+	Point(t, freq, phase float64) (x, y float64)
+}
+
+// Lissajous is the figure of the book: x = sin t, y = sin(freq t + phase).
+type Lissajous struct{}
+
+func (Lissajous) Point(t, freq, phase float64) (x, y float64) {
+	return math.Sin(t), math.Sin(t*freq + phase)
+}
+
+// A Harmonograph is the figure drawn by a pen swung by two pendulums along
+// each axis, one at the base frequency and one at freq, whose swings die
+// away by a factor of e every 1/Damping radians.
+type Harmonograph struct {
+	Damping float64
+}
+
+func (h Harmonograph) Point(t, freq, phase float64) (x, y float64) {
+	decay := math.Exp(-h.Damping * t)
+	x = decay * (math.Sin(t) + math.Sin(t*freq+phase)) / 2
+	y = decay * (math.Sin(t*freq) + math.Cos(t+phase)) / 2
+	return x, y
+}
+
+// Rose is the rose curve r = cos(freq θ + phase), which has petals for each
+// whole-number freq.
+type Rose struct{}
+
+func (Rose) Point(t, freq, phase float64) (x, y float64) {
+	r := math.Cos(t*freq + phase)
+	return r * math.Cos(t), r * math.Sin(t)
+}
+
+// A Hypotrochoid is the figure of a spirograph: a pen at Pen wheel radii
+// from the center of a wheel rolling inside a ring freq times its radius.
+// The phase turns the pen about the wheel's center.
+type Hypotrochoid struct {
+	Pen float64
+}
+
+func (h Hypotrochoid) Point(t, freq, phase float64) (x, y float64) {
+	r := 1 / freq    // the wheel's radius, that of the ring being 1
+	k := (1 - r) / r // turns of the wheel per trip round the ring
+	d := h.Pen * r
+	scale := math.Abs(1-r) + math.Abs(d) // farthest the pen gets from the center
+	x = ((1-r)*math.Cos(t) + d*math.Cos(k*t+phase)) / scale
+	y = ((1-r)*math.Sin(t) - d*math.Sin(k*t+phase)) / scale
+	return x, y
+}
+
+// Lissajous3D is a Lissajous figure in three dimensions, with
+// z = sin(ZFreq t), turned by phase about the vertical axis, tipped Tilt
+// radians toward the viewer and projected onto the screen.
+type Lissajous3D struct {
+	ZFreq float64
+	Tilt  float64
+}
+
+func (l Lissajous3D) Point(t, freq, phase float64) (x, y float64) {
+	x0, y0, z0 := math.Sin(t), math.Sin(t*freq), math.Sin(t*l.ZFreq)
+	x1 := x0*math.Cos(phase) + z0*math.Sin(phase)
+	z1 := z0*math.Cos(phase) - x0*math.Sin(phase)
+	y1 := y0*math.Cos(l.Tilt) - z1*math.Sin(l.Tilt)
+	// A point of the unit cube is at most √3 from the center, however it
+	// is turned.
+	return x1 / math.Sqrt(3), y1 / math.Sqrt(3)
+}
+
+// Curves holds the built-in curves by name, for ParseCurve.
+var Curves = map[string]Curve{
+	"lissajous":    Lissajous{},
+	"harmonograph": Harmonograph{Damping: 0.02},
+	"rose":         Rose{},
+	"hypotrochoid": Hypotrochoid{Pen: 0.8},
+	"lissajous3d":  Lissajous3D{ZFreq: 1.5, Tilt: 0.5},
+}
+
+// CurveNames lists the names in Curves, in the order they are documented.
+var CurveNames = []string{"lissajous", "harmonograph", "rose", "hypotrochoid", "lissajous3d"}
+
+// ParseCurve returns the built-in curve with the given name.
+func ParseCurve(name string) (Curve, error) {
+	c, ok := Curves[name]
+	if !ok {
+		return nil, fmt.Errorf("curve: %q is not one of %v", name, CurveNames)
+	}
+	return c, nil
+}
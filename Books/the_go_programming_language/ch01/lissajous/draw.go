@@ -0,0 +1,127 @@
+package lissajous
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// maxShades is the most shades of each color in a blending palette.
+const maxShades = 16
+
+// shades returns how many shades of each of the n-1 curve colors fit in a
+// blending palette alongside the background.
+func shades(n int) int {
+	return min(maxShades, 255/(n-1))
+}
+
+// framePalette returns the palette of the frames. Without antialiasing it
+// is p.Palette. With it, each curve color k of p.Palette is preceded by
+// its blends with the background, so that a pixel part-covered by a line
+// can take an intermediate shade:
+//
+//	0: background, then for k = 1, 2, ...: shades-1 blends, then color k.
+//
+// The heat map colors pixels by density rather than coverage, so it never
+// blends.
+func (p Params) framePalette() color.Palette {
+	if !p.Antialias || p.Scheme == Heat {
+		return p.Palette
+	}
+	n := shades(len(p.Palette))
+	bg := color.RGBAModel.Convert(p.Palette[0]).(color.RGBA)
+	pal := color.Palette{p.Palette[0]}
+	for _, c := range p.Palette[1:] {
+		fg := color.RGBAModel.Convert(c).(color.RGBA)
+		for level := 1; level <= n; level++ {
+			pal = append(pal, blend(bg, fg, float64(level)/float64(n)))
+		}
+	}
+	return pal
+}
+
+// blend mixes a of fg into bg.
+func blend(bg, fg color.RGBA, a float64) color.RGBA {
+	mix := func(b, f uint8) uint8 { return uint8(float64(b) + (float64(f)-float64(b))*a + 0.5) }
+	return color.RGBA{mix(bg.R, fg.R), mix(bg.G, fg.G), mix(bg.B, fg.B), mix(bg.A, fg.A)}
+}
+
+// A canvas draws anti-aliased lines into a frame whose palette was made by
+// framePalette.
+type canvas struct {
+	img    *image.Paletted
+	shades int
+}
+
+func newCanvas(img *image.Paletted, colors int) *canvas {
+	return &canvas{img, shades(colors)}
+}
+
+// thickLine draws a line in color k of Params.Palette.
+func (c *canvas) thickLine(x0, y0, x1, y1, width float64, k uint8) {
+	thickLine(x0, y0, x1, y1, width, func(x, y int, a float64) { c.plot(x, y, k, a) })
+}
+
+// plot covers a fraction a of pixel (x, y) with color k of Params.Palette.
+// Where lines overlap, the pixel keeps the stronger coverage.
+func (c *canvas) plot(x, y int, k uint8, a float64) {
+	level := int(a*float64(c.shades) + 0.5)
+	if level == 0 || k == 0 || !(image.Point{x, y}.In(c.img.Rect)) {
+		return
+	}
+	if old := int(c.img.ColorIndexAt(x, y)); old != 0 && (old-1)%c.shades+1 >= level {
+		return
+	}
+	c.img.SetColorIndex(x, y, uint8((int(k)-1)*c.shades+level))
+}
+
+// line draws an anti-aliased line between two points given in pixel
+// coordinates, using Xiaolin Wu's algorithm: plot is called with the
+// fraction of each pixel the line covers. The end points are drawn at full
+// strength since the line is one segment of a longer curve.
+func line(x0, y0, x1, y1 float64, plot func(x, y int, a float64)) {
+	steep := math.Abs(y1-y0) > math.Abs(x1-x0)
+	if steep {
+		x0, y0, x1, y1 = y0, x0, y1, x1
+	}
+	if x0 > x1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	gradient := 0.0
+	if dx := x1 - x0; dx > 0 {
+		gradient = (y1 - y0) / dx
+	}
+	for x := math.Floor(x0 + 0.5); x <= math.Floor(x1+0.5); x++ {
+		y := y0 + gradient*(x-x0)
+		fy := math.Floor(y)
+		frac := y - fy
+		if steep {
+			plot(int(fy), int(x), 1-frac)
+			plot(int(fy)+1, int(x), frac)
+		} else {
+			plot(int(x), int(fy), 1-frac)
+			plot(int(x), int(fy)+1, frac)
+		}
+	}
+}
+
+// thickLine draws a line of the given width as parallel anti-aliased
+// lines no more than a pixel apart.
+func thickLine(x0, y0, x1, y1, width float64, plot func(x, y int, a float64)) {
+	if width <= 1 {
+		line(x0, y0, x1, y1, plot)
+		return
+	}
+	// (nx, ny) is the unit normal of the line.
+	dx, dy := x1-x0, y1-y0
+	d := math.Hypot(dx, dy)
+	if d == 0 {
+		dx, d = 1, 1
+	}
+	nx, ny := -dy/d, dx/d
+	n := int(math.Ceil(width))
+	for j := 0; j < n; j++ {
+		off := -(width-1)/2 + (width-1)*float64(j)/float64(n-1)
+		line(x0+nx*off, y0+ny*off, x1+nx*off, y1+ny*off, plot)
+	}
+}
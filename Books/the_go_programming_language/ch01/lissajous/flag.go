@@ -0,0 +1,162 @@
+package lissajous
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RegisterFlags defines a flag on fs for each field of p, named after it,
+// with p's current values as the defaults. After fs is parsed, p holds the
+// values given on the command line. Without -seed, p.Seed is left as it
+// is, which for DefaultParams means a random figure.
+func (p *Params) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&p.Cycles, "cycles", p.Cycles, "number of complete x oscillator revolutions")
+	fs.Float64Var(&p.Res, "res", p.Res, "angular resolution")
+	fs.IntVar(&p.Size, "size", p.Size, "image canvas covers [-size..+size]")
+	fs.IntVar(&p.NFrames, "nframes", p.NFrames, "number of animation frames")
+	fs.IntVar(&p.Delay, "delay", p.Delay, "delay between frames in 10ms units")
+	fs.Var(curveFlag{p}, "curve", "`name` of the figure to draw: one of "+join(CurveNames))
+	fs.Float64Var(&p.Freq, "freq", p.Freq, "relative frequency of y oscillator; 0 picks one from the seed")
+	fs.Func("seed", "`seed` for the random choices, for the same figure every time (default random)", func(s string) error {
+		seed, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		p.Seed = seed
+		return nil
+	})
+	fs.Float64Var(&p.PhaseStep, "phase-step", p.PhaseStep, "phase difference added between frames")
+	fs.Var(paletteFlag{p}, "palette", "`colors`, background first: rainbow, heat, or hex colors such as 000000,00ff00")
+	fs.Func("index", fmt.Sprintf("palette `index` of the curve color (default %d)", p.Index), func(s string) error {
+		i, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return fmt.Errorf("%q is not an index from 0 to 255", s)
+		}
+		p.Index = uint8(i)
+		return nil
+	})
+	fs.IntVar(&p.Frame, "frame", p.Frame, "the frame written by single-image formats")
+	fs.BoolVar(&p.Antialias, "antialias", p.Antialias, "join the samples with anti-aliased lines")
+	fs.Float64Var(&p.Thickness, "thickness", p.Thickness, "width of anti-aliased lines in pixels")
+	fs.Var(&p.Scheme, "scheme", "color `scheme` of the points: one of "+join(Schemes))
+	fs.IntVar(&p.Workers, "workers", p.Workers, "most frames rendered at once; 0 means GOMAXPROCS")
+}
+
+// Main is the main function of the lissajous programs. It defines flags
+// for the fields of p, with p's values as the defaults, and -format and -o
+// for where the animation goes; parses the command line; and writes the
+// animation, in the palette that suits -scheme if it is given without
+// -palette, to standard output unless -o names a file. If that fails, it
+// reports why and exits with status 1.
+func Main(p Params) {
+	p.RegisterFlags(flag.CommandLine)
+	format := GIF
+	flag.Var(&format, "format", "output `format`: one of "+join(Formats))
+	out := flag.String("o", "", "write to `file` instead of standard output")
+	flag.Parse()
+	// A program's own palette may have a single curve color, which would
+	// make every scheme look like Solid, so -scheme alone brings its own.
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["scheme"] && !set["palette"] && p.Scheme != Solid {
+		p.Palette = p.Scheme.DefaultPalette()
+	}
+	var err error
+	if *out == "" {
+		err = Encode(os.Stdout, p, format)
+	} else {
+		err = WriteFile(*out, p, format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lissajous: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// The Set methods make pointers to Scheme and Format satisfy flag.Value.
+
+func (s *Scheme) Set(v string) error {
+	if !slices.Contains(Schemes, Scheme(v)) {
+		return fmt.Errorf("%q is not one of %v", v, Schemes)
+	}
+	*s = Scheme(v)
+	return nil
+}
+
+func (s Scheme) String() string { return string(s) }
+
+func (f *Format) Set(v string) error {
+	if !slices.Contains(Formats, Format(v)) {
+		return fmt.Errorf("%q is not one of %v", v, Formats)
+	}
+	*f = Format(v)
+	return nil
+}
+
+func (f Format) String() string { return string(f) }
+
+// A curveFlag sets p.Curve by name.
+type curveFlag struct{ p *Params }
+
+func (c curveFlag) Set(s string) error {
+	curve, err := ParseCurve(s)
+	if err != nil {
+		return err
+	}
+	c.p.Curve = curve
+	return nil
+}
+
+func (c curveFlag) String() string {
+	if c.p == nil {
+		return ""
+	}
+	// Curves are compared by their Go syntax, since == would panic for
+	// a curve type that cannot be compared, such as one holding a slice.
+	curve := fmt.Sprintf("%#v", c.p.curve())
+	for _, name := range CurveNames {
+		if fmt.Sprintf("%#v", Curves[name]) == curve {
+			return name
+		}
+	}
+	return curve
+}
+
+// A paletteFlag sets p.Palette with ParsePalette.
+type paletteFlag struct{ p *Params }
+
+func (f paletteFlag) Set(s string) error {
+	pal, err := ParsePalette(s)
+	if err != nil {
+		return err
+	}
+	f.p.Palette = pal
+	return nil
+}
+
+func (f paletteFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	var hexes []string
+	for _, c := range f.p.Palette {
+		hexes = append(hexes, strings.TrimPrefix(hex(c), "#"))
+	}
+	return strings.Join(hexes, ",")
+}
+
+// join lists vs separated by commas, for usage messages.
+func join[T ~string](vs []T) string {
+	var b strings.Builder
+	for i, v := range vs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(string(v))
+	}
+	return b.String()
+}
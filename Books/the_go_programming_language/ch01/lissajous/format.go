@@ -0,0 +1,118 @@
+package lissajous
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// A Format is an output format for Encode.
+type Format string
+
+const (
+	GIF   Format = "gif"   // animated GIF
+	PNG   Format = "png"   // the single frame p.Frame as a PNG
+	Sheet Format = "sheet" // a contact sheet of every frame as one PNG
+	APNG  Format = "apng"  // animated PNG
+
+	SVG     Format = "svg"      // the single frame p.Frame as an SVG polyline
+	SMILSVG Format = "svg-smil" // an SVG animated with SMIL
+	CSSSVG  Format = "svg-css"  // an SVG animated with CSS keyframes
+)
+
+// Formats lists every supported format.
+var Formats = []Format{GIF, PNG, Sheet, APNG, SVG, SMILSVG, CSSSVG}
+
+var encoders = map[Format]func(io.Writer, Params) error{
+	GIF:   encodeGIF,
+	PNG:   encodePNG,
+	Sheet: encodeSheet,
+	APNG:  encodeAPNG,
+
+	SVG:     encodeSVG,
+	SMILSVG: encodeSMILSVG,
+	CSSSVG:  encodeCSSSVG,
+}
+
+var contentTypes = map[Format]string{
+	GIF:   "image/gif",
+	PNG:   "image/png",
+	Sheet: "image/png",
+	APNG:  "image/apng",
+
+	SVG:     "image/svg+xml",
+	SMILSVG: "image/svg+xml",
+	CSSSVG:  "image/svg+xml",
+}
+
+// ContentType returns the MIME type of f, for HTTP responses.
+func (f Format) ContentType() string { return contentTypes[f] }
+
+// Key returns a string that identifies what Encode writes for p and f:
+// equal keys mean equal output, byte for byte. Fields that do not change
+// the output, such as Workers, are left out, and those with more than one
+// way of saying the same thing, such as a nil Curve and Lissajous{}, are
+// put one way, so callers can use it to cache encoded images.
+func (p Params) Key(f Format) string {
+	p.Workers = 0
+	if f != PNG && f != SVG {
+		p.Frame = 0 // only the single-frame formats use it
+	}
+	p.Curve = p.curve()
+	if p.Scheme == "" {
+		p.Scheme = Solid
+	}
+	if p.Freq != 0 {
+		p.Seed = 0 // the seed only picks the frequency
+	}
+	// Lines up to a pixel wide are drawn alike, except that SVG keeps any
+	// width but 0.
+	if p.Thickness == 0 || p.Thickness < 1 && f != SVG && f != SMILSVG && f != CSSSVG {
+		p.Thickness = 1
+	}
+	return fmt.Sprintf("%s %#v", f, p)
+}
+
+// Encode writes the animation described by p to out in the format f. All
+// formats share the same frames, so a PNG of frame i is frame i of the GIF.
+func Encode(out io.Writer, p Params, f Format) error {
+	enc, ok := encoders[f]
+	if !ok {
+		return fmt.Errorf("unknown format %q", f)
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := enc(out, p); err != nil {
+		return fmt.Errorf("encoding %s: %w", f, err)
+	}
+	return nil
+}
+
+// WriteFile writes the animation described by p in the format f to the
+// named file, creating it if need be and truncating it if not. Bad
+// parameters leave an existing file alone, and if encoding fails part way
+// the partial file is removed, unless it is not a regular file but, say,
+// /dev/stdout.
+func WriteFile(name string, p Params, f Format) error {
+	if _, ok := encoders[f]; !ok {
+		return fmt.Errorf("unknown format %q", f)
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	err = Encode(out, p, f)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if fi, serr := os.Stat(name); serr == nil && fi.Mode().IsRegular() {
+			os.Remove(name)
+		}
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package lissajous
+
+import (
+	"image"
+	"io"
+	"iter"
+	"runtime"
+)
+
+// Frames returns an iterator over the frames of the animation described by
+// p, which must be valid, paired with their indices, in order. Up to
+// p.Workers frames are rendered at once, ahead of the one being consumed;
+// since each depends only on p and its index, the frames are the same as
+// calling Frame for each in turn. Only those frames are held in memory, so
+// it stays the same however many frames there are.
+func Frames(p Params) iter.Seq2[int, *image.Paletted] {
+	return func(yield func(int, *image.Paletted) bool) {
+		// The frames being rendered, in order. The one being waited for
+		// is no longer in the channel, hence the - 1.
+		pending := make(chan chan *image.Paletted, min(p.workers(), p.NFrames)-1)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			defer close(pending)
+			for i := range p.NFrames {
+				c := make(chan *image.Paletted, 1)
+				select {
+				case pending <- c:
+				case <-done:
+					return
+				}
+				go func() { c <- Frame(p, i) }()
+			}
+		}()
+		i := 0
+		for c := range pending {
+			if !yield(i, <-c) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// workers returns p.Workers, or if it is 0 the number of CPUs Go may use.
+func (p Params) workers() int {
+	if p.Workers != 0 {
+		return p.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// flush sends what has been written to w so far on its way, if w can, as
+// an http.ResponseWriter can. Encoders call it after each frame so that a
+// browser can show the first frames before the last are rendered.
+func flush(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
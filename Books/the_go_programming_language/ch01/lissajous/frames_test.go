@@ -0,0 +1,42 @@
+package lissajous
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// Frames renders on several goroutines; check that it gives the frames
+// Frame does, in order, however many workers there are.
+func TestFramesMatchSerial(t *testing.T) {
+	p := DefaultParams()
+	p.Seed = 2
+	p.Size = 30
+	p.NFrames = 9
+	p.Scheme = ByFrame
+	p.Palette = p.Scheme.DefaultPalette()
+	for _, workers := range []int{1, 2, 4, 16} {
+		t.Run(fmt.Sprint("workers=", workers), func(t *testing.T) {
+			p.Workers = workers
+			n := 0
+			for i, img := range Frames(p) {
+				if i != n {
+					t.Fatalf("got frame %d, want %d", i, n)
+				}
+				if !bytes.Equal(img.Pix, Frame(p, i).Pix) {
+					t.Errorf("frame %d differs from Frame", i)
+				}
+				n++
+			}
+			if n != p.NFrames {
+				t.Errorf("got %d frames, want %d", n, p.NFrames)
+			}
+			// Stopping early must not leave the renderers stuck.
+			for i := range Frames(p) {
+				if i == 2 {
+					break
+				}
+			}
+		})
+	}
+}
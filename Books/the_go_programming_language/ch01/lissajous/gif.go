@@ -0,0 +1,50 @@
+package lissajous
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/gif"
+	"io"
+)
+
+// gifHeaderLen is the length of the header gif.EncodeAll writes for a
+// one-frame GIF: the signature, the screen size, and three bytes saying
+// there is no global color table.
+const gifHeaderLen = 13
+
+// encodeGIF writes the same bytes as gif.EncodeAll would for all the
+// frames, but writes each frame as soon as it is rendered. Each frame is
+// encoded as a one-frame GIF with its own color table, as EncodeAll does,
+// and its header and trailer are dropped.
+func encodeGIF(out io.Writer, p Params) error {
+	side := uint16(2*p.Size + 1)
+	header := []byte("GIF89a")
+	header = binary.LittleEndian.AppendUint16(header, side)
+	header = binary.LittleEndian.AppendUint16(header, side)
+	header = append(header, 0, 0, 0)
+	if p.NFrames > 1 {
+		header = append(header, 0x21, 0xff, 0x0b) // application extension
+		header = append(header, "NETSCAPE2.0"...)
+		header = append(header, 0x03, 0x01)
+		header = binary.LittleEndian.AppendUint16(header, uint16(p.NFrames)) // loop count
+		header = append(header, 0x00)
+	}
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, img := range Frames(p) {
+		buf.Reset()
+		g := gif.GIF{Image: []*image.Paletted{img}, Delay: []int{p.Delay}}
+		if err := gif.EncodeAll(&buf, &g); err != nil {
+			return err
+		}
+		if _, err := out.Write(buf.Bytes()[gifHeaderLen : buf.Len()-1]); err != nil {
+			return err
+		}
+		flush(out)
+	}
+	_, err := out.Write([]byte{0x3b}) // trailer
+	return err
+}
@@ -0,0 +1,37 @@
+package lissajous
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+)
+
+// encodeGIF cuts each frame out of a one-frame gif.EncodeAll by fixed
+// offsets, so check that the result is what EncodeAll makes of them all.
+func TestGIFMatchesEncodeAll(t *testing.T) {
+	for _, nframes := range []int{1, 2, 7} {
+		for _, aa := range []bool{false, true} {
+			p := DefaultParams()
+			p.Seed = 1
+			p.Size = 40
+			p.NFrames = nframes
+			p.Antialias = aa
+			var got bytes.Buffer
+			if err := Encode(&got, p, GIF); err != nil {
+				t.Fatal(err)
+			}
+			anim := gif.GIF{LoopCount: p.NFrames}
+			for i := range p.NFrames {
+				anim.Image = append(anim.Image, Frame(p, i))
+				anim.Delay = append(anim.Delay, p.Delay)
+			}
+			var want bytes.Buffer
+			if err := gif.EncodeAll(&want, &anim); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), want.Bytes()) {
+				t.Errorf("nframes=%d antialias=%v: streamed GIF differs from gif.EncodeAll", nframes, aa)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package lissajous renders animations of Lissajous figures. It is the
+// lissajous function from chapter 1, shared by the animated_gifs programs
+// and the web servers that serve its output.
+package lissajous
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"math/rand"
+)
+
+// Params controls the figure and its animation.
+type Params struct {
+	Cycles    int           // number of complete x oscillator revolutions
+	Res       float64       // angular resolution
+	Size      int           // image canvas covers [-size..+size]
+	NFrames   int           // number of frames in the animation
+	Delay     int           // delay between frames in 10ms units
+	Curve     Curve         // the figure drawn; nil means Lissajous
+	Freq      float64       // relative frequency of y oscillator, or its like in Curve; 0 picks one from Seed
+	Seed      int64         // seeds the random choices, so equal Params give equal output
+	PhaseStep float64       // phase difference added between frames
+	Palette   []color.Color // Palette[0] is the background
+	Index     uint8         // palette index the curve is drawn in
+	Frame     int           // the frame written by single-image formats such as PNG
+	Antialias bool          // join the samples with anti-aliased lines instead of plotting them
+	Thickness float64       // width of anti-aliased and SVG lines in pixels; 0 means 1
+	Scheme    Scheme        // how points are colored; empty means Solid; SVG ignores it
+	Workers   int           // most frames rendered at once; 0 means GOMAXPROCS
+}
+
+// DefaultParams returns the parameters used by the book's program: a black
+// curve on white with a random frequency. Set Seed for reproducible output.
+func DefaultParams() Params {
+	return Params{
+		Cycles:    5,
+		Res:       0.001,
+		Size:      100,
+		NFrames:   64,
+		Delay:     8,
+		PhaseStep: 0.1,
+		Seed:      rand.Int63(),
+		Palette:   []color.Color{color.White, color.Black},
+		Index:     1,
+		Scheme:    Solid,
+	}
+}
+
+// Validate reports whether p describes an animation that can be rendered.
+func (p Params) Validate() error {
+	switch {
+	case p.Cycles < 0:
+		return fmt.Errorf("cycles %d is negative", p.Cycles)
+	case !(p.Res > 0):
+		return fmt.Errorf("res %g is not positive", p.Res)
+	case p.Size < 1:
+		return fmt.Errorf("size %d is less than 1", p.Size)
+	case p.NFrames < 1:
+		return fmt.Errorf("nframes %d is less than 1", p.NFrames)
+	case p.Delay < 0:
+		return fmt.Errorf("delay %d is negative", p.Delay)
+	case len(p.Palette) < 2 || len(p.Palette) > 256:
+		return fmt.Errorf("palette has %d colors, want 2 to 256", len(p.Palette))
+	case p.Frame < 0 || p.Frame >= p.NFrames:
+		return fmt.Errorf("frame %d is outside the %d-frame animation", p.Frame, p.NFrames)
+	case int(p.Index) >= len(p.Palette):
+		return fmt.Errorf("index %d is outside the %d-color palette", p.Index, len(p.Palette))
+	case !p.Scheme.valid():
+		return fmt.Errorf("unknown color scheme %q", p.Scheme)
+	case p.Thickness < 0 || p.Thickness > float64(p.Size):
+		return fmt.Errorf("thickness %g is outside [0, %d]", p.Thickness, p.Size)
+	case p.Workers < 0:
+		return fmt.Errorf("workers %d is negative", p.Workers)
+	}
+	return nil
+}
+
+// Frame returns frame i of the animation described by p, which must be
+// valid. Frames depend only on p and i, so they can be made in any order.
+// i may be p.NFrames or more, for an animation that never ends: the phase
+// keeps advancing, and colors by frame repeat every p.NFrames frames.
+func Frame(p Params, i int) *image.Paletted {
+	rect := image.Rect(0, 0, 2*p.Size+1, 2*p.Size+1)
+	img := image.NewPaletted(rect, p.framePalette())
+	if p.Scheme == Heat {
+		drawHeat(img, p, i)
+		return img
+	}
+	color := p.colorer(i)
+	size := float64(p.Size)
+	if p.Antialias {
+		c := newCanvas(img, len(p.Palette))
+		p.eachStep(i, func(a, b sample, last bool) {
+			if !last {
+				c.thickLine(size+a.x*size, size+a.y*size, size+b.x*size, size+b.y*size, p.Thickness, color(a, b))
+			}
+		})
+		return img
+	}
+	p.eachStep(i, func(pt, next sample, _ bool) {
+		img.SetColorIndex(p.Size+int(pt.x*size+0.5), p.Size+int(pt.y*size+0.5), color(pt, next))
+	})
+	return img
+}
+
+// A sample is a point of the figure at parameter t, with both coordinates
+// in [-1, 1].
+type sample struct{ t, x, y float64 }
+
+// eachSample calls f with each point of frame i in turn, every p.Res along
+// the curve. When antialiasing, the end of the curve is included so that
+// the lines joining the samples reach it. The points are made one at a
+// time rather than gathered, since at fine resolutions a frame has
+// millions of them.
+func (p Params) eachSample(i int, f func(sample)) {
+	curve := p.curve()
+	freq := p.frequency()             // relative frequency of y oscillator
+	phase := float64(i) * p.PhaseStep // phase difference
+	end := float64(p.Cycles) * 2 * math.Pi
+	for t := 0.0; t < end; t += p.Res {
+		x, y := curve.Point(t, freq, phase)
+		f(sample{t, x, y})
+	}
+	if p.Antialias {
+		x, y := curve.Point(end, freq, phase)
+		f(sample{end, x, y})
+	}
+}
+
+// eachStep is like eachSample, but passes f the sample after each one as
+// well, so that f can join them or tell how fast the curve is moving. For
+// the last sample, which has none after it, f is passed the one before it
+// and last is true.
+func (p Params) eachStep(i int, f func(s, next sample, last bool)) {
+	var before, prev sample
+	n := 0
+	p.eachSample(i, func(s sample) {
+		if n > 0 {
+			f(prev, s, false)
+		}
+		before, prev = prev, s
+		n++
+	})
+	switch n {
+	case 0:
+	case 1:
+		f(prev, prev, true)
+	default:
+		f(prev, before, true)
+	}
+}
+
+// curve returns p.Curve, or if it is nil Lissajous.
+func (p Params) curve() Curve {
+	if p.Curve == nil {
+		return Lissajous{}
+	}
+	return p.Curve
+}
+
+// frequency returns p.Freq, or if it is 0 a frequency drawn from p.Seed.
+func (p Params) frequency() float64 {
+	if p.Freq != 0 {
+		return p.Freq
+	}
+	return rand.New(rand.NewSource(p.Seed)).Float64() * 3.0
+}
+
+// Render writes the animation described by p to out as a GIF. The output
+// depends only on p, byte for byte. Render returns an error if p is not
+// valid or if writing to out fails, say because a client went away.
+func Render(out io.Writer, p Params) error {
+	return Encode(out, p, GIF)
+}
@@ -0,0 +1,141 @@
+package lissajous
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"math"
+)
+
+func encodePNG(out io.Writer, p Params) error {
+	return png.Encode(out, Frame(p, p.Frame))
+}
+
+// encodeSheet lays the frames out left to right, top to bottom, in a grid
+// as close to square as possible, with a one-pixel background gap between
+// them.
+func encodeSheet(out io.Writer, p Params) error {
+	cols := int(math.Ceil(math.Sqrt(float64(p.NFrames))))
+	rows := (p.NFrames + cols - 1) / cols
+	cell := 2*p.Size + 1
+	sheet := image.NewPaletted(image.Rect(0, 0, cols*(cell+1)-1, rows*(cell+1)-1), p.framePalette())
+	for i, img := range Frames(p) {
+		x0, y0 := i%cols*(cell+1), i/cols*(cell+1)
+		for y := 0; y < cell; y++ {
+			copy(sheet.Pix[sheet.PixOffset(x0, y0+y):], img.Pix[img.PixOffset(0, y):img.PixOffset(cell, y)])
+		}
+	}
+	return png.Encode(out, sheet)
+}
+
+// encodeAPNG writes an animated PNG. Each frame is encoded as an ordinary
+// PNG, and its image data is then copied into the APNG's frame chunks.
+// Since all frames share a palette, the first frame's header and palette
+// chunks serve for the whole file.
+func encodeAPNG(out io.Writer, p Params) error {
+	w := &chunkWriter{w: out}
+	w.write([]byte("\x89PNG\r\n\x1a\n"))
+	seq := uint32(0)
+	for i, img := range Frames(p) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		chunks, err := readChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			for _, c := range chunks {
+				if c.typ == "IHDR" {
+					w.chunk("IHDR", c.data)
+					w.chunk("acTL", be32(uint32(p.NFrames), 0)) // 0 plays means loop forever
+				} else if c.typ == "PLTE" || c.typ == "tRNS" {
+					w.chunk(c.typ, c.data)
+				}
+			}
+		}
+		size := uint32(2*p.Size + 1)
+		fctl := be32(seq, size, size, 0, 0)
+		fctl = binary.BigEndian.AppendUint16(fctl, uint16(p.Delay)) // delay numerator
+		fctl = binary.BigEndian.AppendUint16(fctl, 100)             // delay denominator: 10ms units
+		fctl = append(fctl, 0, 0)                                   // dispose none, blend source
+		w.chunk("fcTL", fctl)
+		seq++
+		for _, c := range chunks {
+			if c.typ != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				w.chunk("IDAT", c.data)
+			} else {
+				w.chunk("fdAT", append(be32(seq), c.data...))
+				seq++
+			}
+		}
+		if w.err != nil {
+			return w.err
+		}
+		flush(out)
+	}
+	w.chunk("IEND", nil)
+	return w.err
+}
+
+type chunk struct {
+	typ  string
+	data []byte
+}
+
+// readChunks splits an encoded PNG into its chunks.
+func readChunks(b []byte) ([]chunk, error) {
+	var chunks []chunk
+	b = b[8:] // signature
+	for len(b) >= 12 {
+		n := binary.BigEndian.Uint32(b)
+		if uint64(n)+12 > uint64(len(b)) {
+			break
+		}
+		chunks = append(chunks, chunk{string(b[4:8]), b[8 : 8+n]})
+		b = b[12+n:]
+	}
+	if len(b) != 0 {
+		return nil, fmt.Errorf("malformed PNG chunk")
+	}
+	return chunks, nil
+}
+
+// A chunkWriter writes PNG chunks, remembering the first error.
+type chunkWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *chunkWriter) write(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *chunkWriter) chunk(typ string, data []byte) {
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	w.write(be32(uint32(len(data))))
+	w.write([]byte(typ))
+	w.write(data)
+	w.write(be32(crc.Sum32()))
+}
+
+// be32 encodes its arguments as consecutive big-endian uint32s.
+func be32(vs ...uint32) []byte {
+	b := make([]byte, 0, 4*len(vs))
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
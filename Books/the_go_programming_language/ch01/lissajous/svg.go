@@ -0,0 +1,109 @@
+package lissajous
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+)
+
+// The SVG formats draw the same curve as the raster formats, but as a
+// polyline in the canvas's pixel coordinates, so it stays sharp at any
+// scale. The animated forms hold one polyline per frame and step through
+// them with the GIF's timing. A polyline has a single color, so the SVG
+// formats ignore Scheme and draw every frame in Palette[Index]; the line
+// is always smooth, Thickness pixels wide.
+
+func encodeSVG(out io.Writer, p Params) error {
+	w := bufio.NewWriter(out)
+	svgStart(w, p)
+	fmt.Fprintf(w, "<polyline %s points=\"%s\"/>\n", stroke(p), svgPoints(p, p.Frame))
+	fmt.Fprintln(w, "</svg>")
+	return w.Flush()
+}
+
+// encodeSMILSVG animates the points attribute of a single polyline.
+func encodeSMILSVG(out io.Writer, p Params) error {
+	w := bufio.NewWriter(out)
+	svgStart(w, p)
+	fmt.Fprintf(w, "<polyline %s points=\"%s\">\n", stroke(p), svgPoints(p, 0))
+	fmt.Fprintf(w, "<animate attributeName=\"points\" dur=\"%gs\" calcMode=\"discrete\" repeatCount=\"indefinite\" values=\"", duration(p))
+	for i := 0; i < p.NFrames; i++ {
+		if i > 0 {
+			w.WriteString(";")
+		}
+		w.WriteString(svgPoints(p, i))
+	}
+	fmt.Fprintln(w, "\"/>\n</polyline>\n</svg>")
+	return w.Flush()
+}
+
+// encodeCSSSVG shows each frame's polyline for its share of a keyframe
+// animation, offset by its position in the sequence. The offsets divide
+// the loop evenly, as the SMIL form's frames do, even when p.Delay is 0.
+func encodeCSSSVG(out io.Writer, p Params) error {
+	w := bufio.NewWriter(out)
+	svgStart(w, p)
+	fmt.Fprintf(w, "<style>\n.frame { visibility: hidden; animation: lissajous %gs step-end infinite; }\n", duration(p))
+	fmt.Fprintf(w, "@keyframes lissajous { 0%% { visibility: visible; } %g%% { visibility: hidden; } }\n</style>\n", 100/float64(p.NFrames))
+	for i := 0; i < p.NFrames; i++ {
+		delay := float64(i*frameDelay(p)) / 100
+		fmt.Fprintf(w, "<polyline class=\"frame\" style=\"animation-delay: %gs\" %s points=\"%s\"/>\n", delay, stroke(p), svgPoints(p, i))
+	}
+	fmt.Fprintln(w, "</svg>")
+	return w.Flush()
+}
+
+func svgStart(w io.Writer, p Params) {
+	n := 2*p.Size + 1
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", n, n, n, n)
+	fmt.Fprintf(w, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", hex(p.Palette[0]))
+}
+
+func stroke(p Params) string {
+	width := p.Thickness
+	if width == 0 {
+		width = 1
+	}
+	return fmt.Sprintf("fill=\"none\" stroke=\"%s\" stroke-width=\"%g\" stroke-linejoin=\"round\"", hex(p.Palette[p.Index]), width)
+}
+
+// duration returns the length of one loop of the animation in seconds.
+func duration(p Params) float64 {
+	return float64(p.NFrames*frameDelay(p)) / 100
+}
+
+// frameDelay returns how long each frame shows, in 10ms units. A GIF shows
+// zero-delay frames briefly too, so they get one unit.
+func frameDelay(p Params) int { return max(p.Delay, 1) }
+
+// svgPoints returns the points of frame i. Straight segments join the
+// samples, so the curve is sampled about once per pixel it travels rather
+// than at every step of Res.
+func svgPoints(p Params, i int) string {
+	curve, freq, phase := p.curve(), p.frequency(), float64(i)*p.PhaseStep
+	size := float64(p.Size)
+	step := math.Max(p.Res, 1/size)
+	var b strings.Builder
+	end := float64(p.Cycles) * 2 * math.Pi
+	for t := 0.0; ; t += step {
+		t = math.Min(t, end)
+		x, y := curve.Point(t, freq, phase)
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%.2f,%.2f", size+x*size+0.5, size+y*size+0.5)
+		if t == end {
+			break
+		}
+	}
+	return b.String()
+}
+
+// hex returns c as an SVG color such as "#0000ff".
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
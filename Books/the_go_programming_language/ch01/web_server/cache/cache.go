@@ -0,0 +1,139 @@
+// Package cache keeps the images rendered by the web servers in memory, so
+// that asking for the same image twice renders it only once.
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A Cache holds responses by key, evicting the least recently used once
+// their total size would pass a budget. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	budget  int
+	max     int                      // largest body worth keeping
+	size    int                      // total length of the bodies held
+	lru     *list.List               // of *entry, most recently used first
+	entries map[string]*list.Element // by key
+}
+
+type entry struct {
+	key  string
+	body []byte
+}
+
+// New returns an empty cache that holds at most budget bytes of responses.
+// A response is kept only if it is at most a sixteenth of the budget: the
+// copy of a response is made while it is rendered, and with many requests
+// at once, copies as big as the whole budget would use many times it.
+func New(budget int) *Cache {
+	return &Cache{budget: budget, max: budget / 16, lru: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*entry).body, true
+}
+
+func (c *Cache) add(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok || len(body) > c.max {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key, body})
+	c.size += len(body)
+	for c.size > c.budget {
+		old := c.lru.Remove(c.lru.Back()).(*entry)
+		delete(c.entries, old.key)
+		c.size -= len(old.body)
+	}
+}
+
+// ETag returns the entity tag of the response for key. Since a response
+// depends only on its key, the tag is known before the response is
+// rendered, and a client that already has it need not wait for that.
+func ETag(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Serve writes the response for key to w: nothing but 304 Not Modified if
+// the request's If-None-Match names its ETag, the cached body if there is
+// one, and otherwise what render writes, which is kept for next time if
+// render succeeds. Headers such as Content-Type and Cache-Control must be
+// set before calling Serve. The error is render's.
+func (c *Cache) Serve(w http.ResponseWriter, r *http.Request, key string, render func(io.Writer) error) error {
+	etag := ETag(key)
+	w.Header().Set("ETag", etag)
+	if matches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	if body, ok := c.get(key); ok {
+		_, err := w.Write(body)
+		return err
+	}
+	t := &tee{w: w, max: c.max}
+	if err := render(t); err != nil {
+		return err
+	}
+	if !t.over {
+		c.add(key, t.buf.Bytes())
+	}
+	return nil
+}
+
+// matches reports whether an If-None-Match header names etag. Weak tags
+// match too, as RFC 9110 asks.
+func matches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// A tee writes to w and keeps a copy of what it wrote, until the copy
+// would be too big to cache. It passes flushes on, so a response still
+// reaches the client as it is rendered.
+type tee struct {
+	w    http.ResponseWriter
+	buf  bytes.Buffer
+	max  int  // largest copy to keep
+	over bool // the copy was dropped for being bigger than max
+}
+
+func (t *tee) Write(b []byte) (int, error) {
+	n, err := t.w.Write(b)
+	if !t.over {
+		if t.buf.Len()+n > t.max {
+			t.over = true
+			t.buf = bytes.Buffer{}
+		} else {
+			t.buf.Write(b[:n])
+		}
+	}
+	return n, err
+}
+
+func (t *tee) Flush() {
+	if f, ok := t.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"log"
+	"net/http"
+)
+
+//go:embed index.html
+var indexHTML string
+
+var indexTemplate = template.Must(template.New("index").Parse(indexHTML))
+
+// servePage serves the page for exploring the parameters. Its sliders stop
+// at the server's limits, and it shows the image from /image with the
+// page's own query, so a link to the page is a link to the figure.
+func servePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	limits := struct {
+		MaxSize, MaxFrames, MaxCycles int
+		MinRes                        float64
+	}{*maxSize, *maxFrames, *maxCycles, *minRes}
+	if err := indexTemplate.Execute(w, limits); err != nil {
+		log.Printf("%s %s from %s: %v", r.Method, r.URL, r.RemoteAddr, err)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"ch01/lissajous"
+)
+
+// Limits on what a client may ask for. Every frame holds (2*size+1)²
+// pixels, so size and nframes bound the memory one request can use, and
+// cycles and res bound the work per frame.
+var (
+	maxSize   = flag.Int("max-size", 400, "largest size a client may ask for")
+	maxFrames = flag.Int("max-frames", 128, "most frames a client may ask for")
+	maxCycles = flag.Int("max-cycles", 100, "most cycles a client may ask for")
+	minRes    = flag.Float64("min-res", 0.0001, "finest angular resolution a client may ask for")
+	workers   = flag.Int("workers", 0, "most frames one request renders at once; 0 means GOMAXPROCS")
+)
+
+// parseParams reads the lissajous parameters from the request's query,
+// using the defaults for those that are missing. The error describes the
+// first parameter that is malformed or out of bounds, or else the first
+// way in which they do not go together, such as a line thicker than the
+// image.
+func parseParams(r *http.Request) (lissajous.Params, error) {
+	p := lissajous.DefaultParams()
+	p.Workers = *workers
+	if err := intParam(r, "cycles", &p.Cycles, 1, *maxCycles); err != nil {
+		return p, err
+	}
+	if err := floatParam(r, "res", &p.Res, *minRes, 1); err != nil {
+		return p, err
+	}
+	// freq does not change the work, so its bound only keeps figures
+	// recognizable; 0, the default, picks a frequency from the seed.
+	if err := floatParam(r, "freq", &p.Freq, 0, 100); err != nil {
+		return p, err
+	}
+	if err := intParam(r, "size", &p.Size, 1, *maxSize); err != nil {
+		return p, err
+	}
+	if err := intParam(r, "nframes", &p.NFrames, 1, *maxFrames); err != nil {
+		return p, err
+	}
+	if err := intParam(r, "frame", &p.Frame, 0, p.NFrames-1); err != nil {
+		return p, err
+	}
+	// A GIF stores the delay in 16 bits.
+	if err := intParam(r, "delay", &p.Delay, 0, math.MaxUint16); err != nil {
+		return p, err
+	}
+	if s := r.FormValue("antialias"); s != "" {
+		aa, err := strconv.ParseBool(s)
+		if err != nil {
+			return p, fmt.Errorf("antialias: %q is not true or false", s)
+		}
+		p.Antialias = aa
+	}
+	if err := floatParam(r, "thickness", &p.Thickness, 0, 10); err != nil {
+		return p, err
+	}
+	if s := r.FormValue("curve"); s != "" {
+		c, err := lissajous.ParseCurve(s)
+		if err != nil {
+			return p, err
+		}
+		p.Curve = c
+	}
+	if s := r.FormValue("scheme"); s != "" {
+		if !slices.Contains(lissajous.Schemes, lissajous.Scheme(s)) {
+			return p, fmt.Errorf("scheme: %q is not one of %v", s, lissajous.Schemes)
+		}
+		p.Scheme = lissajous.Scheme(s)
+	}
+	// The server's own palette suits a solid curve; the other schemes
+	// default to a gradient.
+	p.Palette, p.Index = palette, blueIndex
+	if s := r.FormValue("palette"); s != "" {
+		pal, err := lissajous.ParsePalette(s)
+		if err != nil {
+			return p, err
+		}
+		p.Palette, p.Index = pal, 1
+	} else if p.Scheme != lissajous.Solid {
+		p.Palette = p.Scheme.DefaultPalette()
+	}
+	if s := r.FormValue("seed"); s != "" {
+		seed, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return p, fmt.Errorf("seed: %q is not an integer", s)
+		}
+		p.Seed = seed
+	}
+	// Checking here rather than leaving it to Encode means a bad mix gets a
+	// 400, not an empty image sent after the headers.
+	return p, p.Validate()
+}
+
+// intParam stores the named query parameter in *v if it is present and
+// within [min, max].
+func intParam(r *http.Request, name string, v *int, min, max int) error {
+	s := r.FormValue(name)
+	if s == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("%s: %q is not an integer", name, s)
+	}
+	if n < min || n > max {
+		return fmt.Errorf("%s: %d is out of range [%d, %d]", name, n, min, max)
+	}
+	*v = n
+	return nil
+}
+
+// floatParam is like intParam for floating-point parameters.
+func floatParam(r *http.Request, name string, v *float64, min, max float64) error {
+	s := r.FormValue(name)
+	if s == "" {
+		return nil
+	}
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(x) {
+		return fmt.Errorf("%s: %q is not a number", name, s)
+	}
+	if x < min || x > max {
+		return fmt.Errorf("%s: %g is out of range [%g, %g]", name, x, min, max)
+	}
+	*v = x
+	return nil
+}
+
+// parseFormat reads the output format from the query: gif (the default),
+// png for the single frame given by frame=, sheet for a contact sheet of
+// every frame, apng, or svg. An svg is a still of one frame unless
+// animate=smil or animate=css asks for it to be animated.
+func parseFormat(r *http.Request) (lissajous.Format, error) {
+	s := r.FormValue("format")
+	if s == "" {
+		return lissajous.GIF, nil
+	}
+	if s == "svg" {
+		switch a := r.FormValue("animate"); a {
+		case "":
+			return lissajous.SVG, nil
+		case "smil":
+			return lissajous.SMILSVG, nil
+		case "css":
+			return lissajous.CSSSVG, nil
+		default:
+			return "", fmt.Errorf("animate: %q is not smil or css", a)
+		}
+	}
+	for _, f := range lissajous.Formats {
+		if lissajous.Format(s) == f {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("format: %q is not one of %v", s, lissajous.Formats)
+}
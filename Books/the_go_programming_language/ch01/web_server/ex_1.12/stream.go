@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"ch01/lissajous"
+)
+
+// A live stream is an endless multipart/x-mixed-replace response from
+// /stream, each part a PNG of the next frame, which a browser shows in an
+// <img> as an animation that never stops. The phase keeps advancing rather
+// than going back to frame 0 after nframes frames. Streams have names, and
+// a POST to /stream/update with a name and new parameters changes every
+// stream of that name from its next frame on, so one control can drive
+// several displays.
+
+const streamBoundary = "lissajous-frame"
+
+// streams holds the update channel of each running stream, by name.
+var streams = struct {
+	sync.Mutex
+	m map[string]map[chan lissajous.Params]bool
+}{m: make(map[string]map[chan lissajous.Params]bool)}
+
+// serveStream streams the figure given by the query until the client goes
+// away. The stream is named by stream=, or if that is missing by a random
+// name reported in the X-Lissajous-Stream header.
+func serveStream(w http.ResponseWriter, r *http.Request) {
+	p, err := parseParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.FormValue("stream")
+	if name == "" {
+		name = strconv.FormatUint(rand.Uint64(), 36)
+	}
+	updates := make(chan lissajous.Params, 1)
+	join(name, updates)
+	defer leave(name, updates)
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+streamBoundary)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Lissajous-Stream", name)
+	rc := http.NewResponseController(w)
+	tick := time.NewTicker(frameInterval(p))
+	defer tick.Stop()
+	var buf bytes.Buffer
+	for i := 0; ; i++ {
+		buf.Reset()
+		if err := png.Encode(&buf, lissajous.Frame(p, i)); err != nil {
+			log.Printf("%s %s from %s: %v", r.Method, r.URL, r.RemoteAddr, err)
+			return
+		}
+		// Giving the length lets a browser show the frame as soon as it
+		// has it, instead of when the next boundary arrives.
+		fmt.Fprintf(w, "--%s\r\nContent-Type: image/png\r\nContent-Length: %d\r\n\r\n", streamBoundary, buf.Len())
+		buf.WriteString("\r\n")
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return // the client has gone away
+		}
+		rc.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case p = <-updates:
+			tick.Reset(frameInterval(p))
+		case <-tick.C:
+		}
+	}
+}
+
+// serveStreamUpdate gives the streams named by stream= the parameters in
+// the rest of the request, which like those of /image take their defaults
+// when missing.
+func serveStreamUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "use POST to update a stream", http.StatusMethodNotAllowed)
+		return
+	}
+	p, err := parseParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.FormValue("stream")
+	if update(name, p) == 0 {
+		http.Error(w, fmt.Sprintf("no stream named %q is running", name), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// frameInterval returns the time between frames of p. A GIF shows frames
+// with no delay briefly too, so the stream gives them 10ms.
+func frameInterval(p lissajous.Params) time.Duration {
+	return time.Duration(max(p.Delay, 1)) * 10 * time.Millisecond
+}
+
+func join(name string, c chan lissajous.Params) {
+	streams.Lock()
+	defer streams.Unlock()
+	if streams.m[name] == nil {
+		streams.m[name] = make(map[chan lissajous.Params]bool)
+	}
+	streams.m[name][c] = true
+}
+
+func leave(name string, c chan lissajous.Params) {
+	streams.Lock()
+	defer streams.Unlock()
+	delete(streams.m[name], c)
+	if len(streams.m[name]) == 0 {
+		delete(streams.m, name)
+	}
+}
+
+// update sends p to the streams with the given name and reports how many
+// there are. An update a stream has not yet taken is replaced, so only
+// the latest counts.
+func update(name string, p lissajous.Params) int {
+	streams.Lock()
+	defer streams.Unlock()
+	for c := range streams.m[name] {
+		select {
+		case <-c:
+		default:
+		}
+		c <- p
+	}
+	return len(streams.m[name])
+}
@@ -3,61 +3,65 @@
 package main
 
 import (
-	"image"
+	"flag"
 	"image/color"
-	"image/gif"
 	"io"
 	"log"
-	"math"
-	"math/rand"
 	"net/http"
+	"strconv"
+
+	"ch01/lissajous"
+	"ch01/web_server/cache"
 )
 
-func main() {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		lissajous(w)
-	})
-	log.Fatal(http.ListenAndServe("localhost:8000", nil))
+var cacheBytes = flag.Int("cache-bytes", 64<<20, "most bytes of rendered images to keep in memory, each at most a sixteenth of it; 0 turns caching off")
+
+// This block defines the color palette and constants for color indices
+var palette = []color.Color{
+	color.RGBA{0x00, 0x00, 0x00, 0xFF},
+	color.RGBA{0xFF, 0xFF, 0xFF, 0xFF},
+	color.RGBA{0xFF, 0x00, 0x00, 0xFF},
+	color.RGBA{0x00, 0xFF, 0x00, 0xFF},
+	color.RGBA{0x00, 0x00, 0xFF, 0xFF},
 }
 
-func lissajous(out io.Writer) {
-	// This block defines the color palette and constants for color indices
-	palette := []color.Color{
-		color.RGBA{0x00, 0x00, 0x00, 0xFF},
-		color.RGBA{0xFF, 0xFF, 0xFF, 0xFF},
-		color.RGBA{0xFF, 0x00, 0x00, 0xFF},
-		color.RGBA{0x00, 0xFF, 0x00, 0xFF},
-		color.RGBA{0x00, 0x00, 0xFF, 0xFF},
-	}
-	const (
-		whiteIndex = 0
-		blackIndex = 1
-		redIndex   = 2
-		greenIndex = 3
-		blueIndex  = 4
-	)
-	const (
-		cycles  = 5     // number of complete x oscillator revolutions
-		res     = 0.001 // angular resolution
-		size    = 100   // image canvas covers [-size..+size]
-		nframes = 64    // number of frames in the animation
-		delay   = 8     // delay between frames in 10ms units
-	)
+const (
+	blackIndex = 0
+	whiteIndex = 1
+	redIndex   = 2
+	greenIndex = 3
+	blueIndex  = 4
+)
 
-	freq := rand.Float64() * 3.0        // relative frequency of y oscillator
-	anim := gif.GIF{LoopCount: nframes} // composite literal for gif.GIF struct
-	phase := 0.0                        // phase difference
-	for i := 0; i < nframes; i++ {
-		rect := image.Rect(0, 0, 2*size+1, 2*size+1)
-		img := image.NewPaletted(rect, palette)
-		for t := 0.0; t < cycles*2*math.Pi; t += res {
-			x := math.Sin(t)
-			y := math.Sin(t*freq + phase)
-			img.SetColorIndex(size+int(x*size+0.5), size+int(y*size+0.5), blueIndex)
+func main() {
+	flag.Parse()
+	images := cache.New(*cacheBytes)
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		p := lissajous.DefaultParams()
+		p.Palette = palette
+		p.Index = blueIndex
+		// ?seed= asks for a particular figure again; only such a URL always
+		// names the same image, so only then may the browser reuse it.
+		w.Header().Set("Cache-Control", "no-cache")
+		if s := r.FormValue("seed"); s != "" {
+			seed, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				http.Error(w, "seed: "+strconv.Quote(s)+" is not an integer", http.StatusBadRequest)
+				return
+			}
+			p.Seed = seed
+			w.Header().Set("Cache-Control", "public, max-age=86400")
 		}
-		phase += 0.1
-		anim.Delay = append(anim.Delay, delay)
-		anim.Image = append(anim.Image, img)
-	}
-	gif.EncodeAll(out, &anim) // Note: using gif.EncodeAll instead of gif.Encode
+		w.Header().Set("X-Lissajous-Seed", strconv.FormatInt(p.Seed, 10))
+		w.Header().Set("Content-Type", lissajous.GIF.ContentType())
+		err := images.Serve(w, r, p.Key(lissajous.GIF), func(out io.Writer) error {
+			return lissajous.Render(out, p)
+		})
+		if err != nil {
+			// The response has already started, so the client cannot be
+			// told; most often it has simply gone away.
+			log.Printf("%s %s from %s: %v", r.Method, r.URL, r.RemoteAddr, err)
+		}
+	})
+	log.Fatal(http.ListenAndServe("localhost:8000", nil))
 }
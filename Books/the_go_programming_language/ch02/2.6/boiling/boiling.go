@@ -0,0 +1,17 @@
+// Boiling prints the boiling point of water, as ../../programs/boiling.go
+// does, but through tempconv.Format so that the figures read as cf's do.
+// The book's listing stays as it is: it is a single file run on its own,
+// outside this module, so it cannot import tempconv.
+package main
+
+import (
+	"fmt"
+
+	"2.6/tempconv"
+)
+
+func main() {
+	f := tempconv.BoilingF
+	opts := tempconv.FormatOptions{Precision: 2, TrimZeros: true}
+	fmt.Printf("boiling point = %s or %s\n", tempconv.Format(f, opts), tempconv.Format(tempconv.FToC(f), opts))
+}
@@ -1,22 +1,244 @@
+// Cf converts temperatures between scales. Readings come from the
+// arguments or, when there are none, one per line from standard input,
+// so cf can sit in a pipeline as a filter.
+//
+//	cf -to K 98.6F 20C
+//	cf -from F -to C -format csv < sensor.log
+//
+// Flags and readings may come in any order, and negative readings such as
+// -40 or -40C are taken as readings, not flags. After --, every argument
+// is a reading.
 package main
 
 import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"2.6/tempconv" // Importing the tempconv package
 )
 
+var (
+	from   = flag.String("from", "", "scale of readings given without a unit (default: both F and C)")
+	to     = flag.String("to", "", "scale to convert to (default: every other scale)")
+	format = flag.String("format", "text", "output format: text, csv or json")
+	prec   = flag.Int("prec", 2, "digits after the decimal point in text output; -1 for all")
+)
+
+// A conversion is one line of output.
+type conversion struct {
+	Input string               `json:"input"`
+	From  tempconv.Temperature `json:"from"`
+	To    tempconv.Temperature `json:"to"`
+}
+
 func main() {
-	for _, arg := range os.Args[1:] {
-		t, err := strconv.ParseFloat(arg, 64)
+	flags, readings := splitArgs(os.Args[1:])
+	flag.CommandLine.Parse(flags) // exits on a bad flag
+	readings = append(readings, flag.Args()...)
+	var (
+		fromScale, toScale *tempconv.Scale
+		err                error
+	)
+	if fromScale, err = scaleFlag(*from); err == nil {
+		toScale, err = scaleFlag(*to)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cf: %v\n", err)
+		os.Exit(2)
+	}
+	w, err := newWriter(os.Stdout, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cf: %v\n", err)
+		os.Exit(2)
+	}
+
+	ok := true
+	convert := func(arg string) {
+		cs, err := convertAll(arg, fromScale, toScale)
+		if len(cs) > 0 {
+			err = errors.Join(err, w(cs))
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "cf: %v\n", err)
-			os.Exit(1)
+			ok = false
 		}
-		f := tempconv.Fahrenheit(t)
-		c := tempconv.Celsius(t)
-		fmt.Printf("%s = %s, %s = %s\n", f, tempconv.FToC(f), c, tempconv.CToF(c))
 	}
+	if len(readings) > 0 {
+		for _, arg := range readings {
+			convert(arg)
+		}
+	} else {
+		input := bufio.NewScanner(os.Stdin)
+		for input.Scan() {
+			if line := strings.TrimSpace(input.Text()); line != "" {
+				convert(line)
+			}
+		}
+		if err := input.Err(); err != nil {
+			fmt.Fprintf(os.Stderr, "cf: %v\n", err)
+			ok = false
+		}
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// splitArgs separates the flags in args, with their values, from the
+// readings. Unlike the flag package, it does not stop at the first reading,
+// and it takes an argument such as -40 or -.5C for a reading, since no flag
+// starts with a digit or a point.
+func splitArgs(args []string) (flags, readings []string) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--":
+			return flags, append(readings, args[i+1:]...)
+		case len(a) > 1 && a[0] == '-' && (a[1] == '.' || '0' <= a[1] && a[1] <= '9'):
+			readings = append(readings, a)
+		case len(a) > 1 && a[0] == '-':
+			flags = append(flags, a)
+			// The next argument is this flag's value, even if it is -1.
+			if takesValue(a) && i+1 < len(args) {
+				i++
+				flags = append(flags, args[i])
+			}
+		default:
+			readings = append(readings, a)
+		}
+	}
+	return flags, readings
 }
+
+// takesValue reports whether arg is a flag, such as -prec, whose value is
+// the next argument.
+func takesValue(arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if strings.Contains(name, "=") {
+		return false
+	}
+	f := flag.Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return !ok || !b.IsBoolFlag()
+}
+
+// scaleFlag parses the value of -from or -to; nil means the flag is unset.
+func scaleFlag(s string) (*tempconv.Scale, error) {
+	if s == "" {
+		return nil, nil
+	}
+	sc, err := tempconv.ParseScale(s)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// convertAll converts one reading to the -to scale, or to every other
+// scale if -to is unset. A bare number with no -from is read both as
+// Fahrenheit and as Celsius, as the original cf did; if only one of those
+// is possible, as for -300, its conversions are returned along with the
+// error for the other.
+func convertAll(arg string, from, to *tempconv.Scale) ([]conversion, error) {
+	var readings []tempconv.Temperature
+	if from != nil {
+		t, err := tempconv.ParseIn(arg, *from)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, t)
+	} else if v, err := strconv.ParseFloat(arg, 64); err == nil {
+		readings = append(readings, tempconv.Fahrenheit(v), tempconv.Celsius(v))
+	} else {
+		t, err := tempconv.Parse(arg)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, t)
+	}
+
+	var (
+		cs   []conversion
+		errs []error
+	)
+readings:
+	for _, t := range readings {
+		var tcs []conversion
+		for _, s := range tempconv.Scales {
+			if to != nil && s != *to || to == nil && s == t.Scale() {
+				continue
+			}
+			r, err := tempconv.ConvertChecked(t, s)
+			if err != nil {
+				errs = append(errs, err)
+				continue readings
+			}
+			tcs = append(tcs, conversion{arg, t, r})
+		}
+		cs = append(cs, tcs...)
+	}
+	return cs, errors.Join(errs...)
+}
+
+// newWriter returns a function that writes the conversions of one
+// reading to out in the named format.
+func newWriter(out io.Writer, format string) (func([]conversion) error, error) {
+	switch format {
+	case "text":
+		opts := tempconv.FormatOptions{Precision: *prec, TrimZeros: true}
+		return func(cs []conversion) error {
+			// Readings of the same input share a line: "98.6°F = 37°C = 310.15K".
+			var b strings.Builder
+			for i, c := range cs {
+				if i == 0 || c.From != cs[i-1].From {
+					if i > 0 {
+						b.WriteString(", ")
+					}
+					b.WriteString(tempconv.Format(c.From, opts))
+				}
+				b.WriteString(" = " + tempconv.Format(c.To, opts))
+			}
+			_, err := fmt.Fprintln(out, b.String())
+			return err
+		}, nil
+	case "csv":
+		w := csv.NewWriter(out)
+		header := []string{"input", "value", "unit", "result", "result_unit"}
+		return func(cs []conversion) error {
+			if header != nil {
+				w.Write(header)
+				header = nil
+			}
+			for _, c := range cs {
+				w.Write([]string{c.Input, number(c.From), c.From.Scale().String(), number(c.To), c.To.Scale().String()})
+			}
+			w.Flush()
+			return w.Error()
+		}, nil
+	case "json":
+		enc := json.NewEncoder(out)
+		return func(cs []conversion) error {
+			for _, c := range cs {
+				if err := enc.Encode(c); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// number writes the value of t without its unit or any rounding.
+func number(t tempconv.Temperature) string { return fmt.Sprintf("%g", t) }
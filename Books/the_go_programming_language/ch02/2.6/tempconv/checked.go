@@ -0,0 +1,71 @@
+package tempconv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotFinite is the error returned by the checked constructors and
+// conversions for a reading that is NaN or infinite, which no thermometer
+// gives and which cannot be written as JSON.
+var ErrNotFinite = errors.New("temperature is not a finite number")
+
+// ErrBelowAbsoluteZero is the error returned by the checked constructors
+// and conversions for a reading colder than absolute zero on its scale.
+type ErrBelowAbsoluteZero struct {
+	Temp Temperature
+}
+
+func (e *ErrBelowAbsoluteZero) Error() string {
+	return fmt.Sprintf("tempconv: %s is below absolute zero (%s)", e.Temp, AbsoluteZero(e.Temp.Scale()))
+}
+
+// AbsoluteZero returns absolute zero on the scale s.
+func AbsoluteZero(s Scale) Temperature { return s.temperature(scales[s].zero) }
+
+// Check reports whether t is physically possible, returning an error
+// wrapping ErrNotFinite if it is NaN or infinite, and an
+// *ErrBelowAbsoluteZero if it is colder than absolute zero.
+func Check(t Temperature) error {
+	v, s := value(t)
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return fmt.Errorf("tempconv: %s: %w", t, ErrNotFinite)
+	case v < scales[s].zero:
+		return &ErrBelowAbsoluteZero{t}
+	}
+	return nil
+}
+
+func NewCelsius(v float64) (Celsius, error)       { return checked(Celsius(v)) }
+func NewFahrenheit(v float64) (Fahrenheit, error) { return checked(Fahrenheit(v)) }
+func NewKelvin(v float64) (Kelvin, error)         { return checked(Kelvin(v)) }
+func NewRankine(v float64) (Rankine, error)       { return checked(Rankine(v)) }
+func NewReaumur(v float64) (Reaumur, error)       { return checked(Reaumur(v)) }
+
+func checked[T Temperature](t T) (T, error) {
+	if err := Check(t); err != nil {
+		var zero T
+		return zero, err
+	}
+	return t, nil
+}
+
+// ConvertChecked is like Convert but fails if t is not a finite number or
+// is below absolute zero.
+func ConvertChecked(t Temperature, to Scale) (Temperature, error) {
+	if err := Check(t); err != nil {
+		return nil, err
+	}
+	// Rounding can move absolute zero a hair away from itself on the new
+	// scale, so it is mapped exactly.
+	if v, s := value(t); v == scales[s].zero {
+		return AbsoluteZero(to), nil
+	}
+	r := Convert(t, to)
+	if Check(r) != nil {
+		r = AbsoluteZero(to)
+	}
+	return r, nil
+}
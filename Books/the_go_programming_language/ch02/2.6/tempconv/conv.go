@@ -1,10 +1,35 @@
 package tempconv
 
-func CToF(c Celsius) Fahrenheit { return Fahrenheit(c*9/5 + 32) }
-func FToC(f Fahrenheit) Celsius { return Celsius((f - 32) * 5 / 9) }
+// Convert converts t to the scale to. The result's dynamic type is the
+// package's type for that scale, e.g. Fahrenheit for FahrenheitScale.
+func Convert(t Temperature, to Scale) Temperature {
+	v, from := value(t)
+	return to.temperature(ConvertValue(v, from, to))
+}
 
-func CToK(c Celsius) Kelvin { return Kelvin(c + 273.15) }
-func KToC(k Kelvin) Celsius { return Celsius(k - 273.15) }
+// value returns the reading held by t and the scale it is on. Temperatures
+// implemented outside the package are read through ToKelvin.
+func value(t Temperature) (float64, Scale) {
+	switch t := t.(type) {
+	case Celsius:
+		return float64(t), CelsiusScale
+	case Fahrenheit:
+		return float64(t), FahrenheitScale
+	case Kelvin:
+		return float64(t), KelvinScale
+	case Rankine:
+		return float64(t), RankineScale
+	case Reaumur:
+		return float64(t), ReaumurScale
+	}
+	return float64(t.ToKelvin()), KelvinScale
+}
 
-func FToK(f Fahrenheit) Kelvin { return CToK(FToC(f)) }
-func KToF(k Kelvin) Fahrenheit { return CToF(KToC(k)) }
+func CToF(c Celsius) Fahrenheit { return Convert(c, FahrenheitScale).(Fahrenheit) }
+func FToC(f Fahrenheit) Celsius { return Convert(f, CelsiusScale).(Celsius) }
+
+func CToK(c Celsius) Kelvin { return Convert(c, KelvinScale).(Kelvin) }
+func KToC(k Kelvin) Celsius { return Convert(k, CelsiusScale).(Celsius) }
+
+func FToK(f Fahrenheit) Kelvin { return Convert(f, KelvinScale).(Kelvin) }
+func KToF(k Kelvin) Fahrenheit { return Convert(k, FahrenheitScale).(Fahrenheit) }
@@ -0,0 +1,115 @@
+package tempconv
+
+import "fmt"
+
+// A Delta is a difference between two temperatures, such as "10°C warmer".
+// Unlike a Temperature it converts between scales without an offset, so
+// a 10°C rise is an 18°F rise, not 50°F.
+type Delta interface {
+	ToKelvin() KelvinDelta
+	Scale() Scale
+	String() string
+}
+
+type (
+	CelsiusDelta    float64
+	FahrenheitDelta float64
+	KelvinDelta     float64
+	RankineDelta    float64
+	ReaumurDelta    float64
+)
+
+func (d CelsiusDelta) String() string    { return fmt.Sprintf("Δ%g°C", d) }
+func (d FahrenheitDelta) String() string { return fmt.Sprintf("Δ%g°F", d) }
+func (d KelvinDelta) String() string     { return fmt.Sprintf("Δ%gK", d) }
+func (d RankineDelta) String() string    { return fmt.Sprintf("Δ%g°R", d) }
+func (d ReaumurDelta) String() string    { return fmt.Sprintf("Δ%g°Ré", d) }
+
+func (d CelsiusDelta) Scale() Scale    { return CelsiusScale }
+func (d FahrenheitDelta) Scale() Scale { return FahrenheitScale }
+func (d KelvinDelta) Scale() Scale     { return KelvinScale }
+func (d RankineDelta) Scale() Scale    { return RankineScale }
+func (d ReaumurDelta) Scale() Scale    { return ReaumurScale }
+
+func (d CelsiusDelta) ToKelvin() KelvinDelta    { return ConvertDelta(d, KelvinScale).(KelvinDelta) }
+func (d FahrenheitDelta) ToKelvin() KelvinDelta { return ConvertDelta(d, KelvinScale).(KelvinDelta) }
+func (d KelvinDelta) ToKelvin() KelvinDelta     { return d }
+func (d RankineDelta) ToKelvin() KelvinDelta    { return ConvertDelta(d, KelvinScale).(KelvinDelta) }
+func (d ReaumurDelta) ToKelvin() KelvinDelta    { return ConvertDelta(d, KelvinScale).(KelvinDelta) }
+
+// Add returns the temperature d warmer than c. Sub returns the difference
+// c - o. The other scales have the same pair of methods.
+func (c Celsius) Add(d CelsiusDelta) Celsius          { return c + Celsius(d) }
+func (c Celsius) Sub(o Celsius) CelsiusDelta          { return CelsiusDelta(c - o) }
+func (f Fahrenheit) Add(d FahrenheitDelta) Fahrenheit { return f + Fahrenheit(d) }
+func (f Fahrenheit) Sub(o Fahrenheit) FahrenheitDelta { return FahrenheitDelta(f - o) }
+func (k Kelvin) Add(d KelvinDelta) Kelvin             { return k + Kelvin(d) }
+func (k Kelvin) Sub(o Kelvin) KelvinDelta             { return KelvinDelta(k - o) }
+func (r Rankine) Add(d RankineDelta) Rankine          { return r + Rankine(d) }
+func (r Rankine) Sub(o Rankine) RankineDelta          { return RankineDelta(r - o) }
+func (r Reaumur) Add(d ReaumurDelta) Reaumur          { return r + Reaumur(d) }
+func (r Reaumur) Sub(o Reaumur) ReaumurDelta          { return ReaumurDelta(r - o) }
+
+// ConvertDeltaValue converts the difference v from one scale to another.
+// It panics if either scale is unknown.
+func ConvertDeltaValue(v float64, from, to Scale) float64 {
+	if from == to {
+		return v
+	}
+	f, t := scales[from], scales[to]
+	return v * (f.num * t.den) / (f.den * t.num)
+}
+
+// ConvertDelta converts d to the scale to.
+func ConvertDelta(d Delta, to Scale) Delta {
+	v, from := deltaValue(d)
+	return to.delta(ConvertDeltaValue(v, from, to))
+}
+
+// AddDelta returns the temperature d warmer than t, on t's scale.
+func AddDelta(t Temperature, d Delta) Temperature {
+	v, s := value(t)
+	w, from := deltaValue(d)
+	return s.temperature(v + ConvertDeltaValue(w, from, s))
+}
+
+// Diff returns the difference a - b, on a's scale.
+func Diff(a, b Temperature) Delta {
+	v, s := value(a)
+	w, _ := value(Convert(b, s))
+	return s.delta(v - w)
+}
+
+// deltaValue is the Delta counterpart of value.
+func deltaValue(d Delta) (float64, Scale) {
+	switch d := d.(type) {
+	case CelsiusDelta:
+		return float64(d), CelsiusScale
+	case FahrenheitDelta:
+		return float64(d), FahrenheitScale
+	case KelvinDelta:
+		return float64(d), KelvinScale
+	case RankineDelta:
+		return float64(d), RankineScale
+	case ReaumurDelta:
+		return float64(d), ReaumurScale
+	}
+	return float64(d.ToKelvin()), KelvinScale
+}
+
+// delta returns v as a Delta of the package's type for s.
+func (s Scale) delta(v float64) Delta {
+	switch s {
+	case CelsiusScale:
+		return CelsiusDelta(v)
+	case FahrenheitScale:
+		return FahrenheitDelta(v)
+	case KelvinScale:
+		return KelvinDelta(v)
+	case RankineScale:
+		return RankineDelta(v)
+	case ReaumurScale:
+		return ReaumurDelta(v)
+	}
+	panic("tempconv: unknown scale " + s.String())
+}
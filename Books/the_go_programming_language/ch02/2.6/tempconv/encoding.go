@@ -0,0 +1,110 @@
+package tempconv
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonTemperature is the JSON form of a reading, e.g. {"value":21.5,"unit":"C"}.
+type jsonTemperature struct {
+	Value *float64 `json:"value"`
+	Unit  string   `json:"unit"`
+}
+
+func (c Celsius) MarshalJSON() ([]byte, error)    { return marshalJSON(c) }
+func (f Fahrenheit) MarshalJSON() ([]byte, error) { return marshalJSON(f) }
+func (k Kelvin) MarshalJSON() ([]byte, error)     { return marshalJSON(k) }
+func (r Rankine) MarshalJSON() ([]byte, error)    { return marshalJSON(r) }
+func (r Reaumur) MarshalJSON() ([]byte, error)    { return marshalJSON(r) }
+
+func marshalJSON(t Temperature) ([]byte, error) {
+	v, s := value(t)
+	return json.Marshal(jsonTemperature{&v, s.String()})
+}
+
+// The UnmarshalJSON methods accept the object written by MarshalJSON, a
+// string such as "21.5°C", or a bare number on the receiver's scale. A
+// reading on another scale is converted to the receiver's scale, and one
+// below absolute zero is rejected.
+func (c *Celsius) UnmarshalJSON(b []byte) error    { return unmarshalJSON(c, b) }
+func (f *Fahrenheit) UnmarshalJSON(b []byte) error { return unmarshalJSON(f, b) }
+func (k *Kelvin) UnmarshalJSON(b []byte) error     { return unmarshalJSON(k, b) }
+func (r *Rankine) UnmarshalJSON(b []byte) error    { return unmarshalJSON(r, b) }
+func (r *Reaumur) UnmarshalJSON(b []byte) error    { return unmarshalJSON(r, b) }
+
+func unmarshalJSON[T Temperature](p *T, b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	switch b[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return set(p, s)
+	case '{':
+		var j jsonTemperature
+		if err := json.Unmarshal(b, &j); err != nil {
+			return err
+		}
+		if j.Value == nil {
+			return fmt.Errorf("tempconv: JSON temperature %s has no value", b)
+		}
+		s, err := ParseScale(j.Unit)
+		if err != nil {
+			return err
+		}
+		return store(p, s.temperature(*j.Value))
+	}
+	var v float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("tempconv: cannot unmarshal %s into a temperature", b)
+	}
+	return store(p, (*p).Scale().temperature(v))
+}
+
+func (c Celsius) MarshalText() ([]byte, error)    { return []byte(c.String()), nil }
+func (f Fahrenheit) MarshalText() ([]byte, error) { return []byte(f.String()), nil }
+func (k Kelvin) MarshalText() ([]byte, error)     { return []byte(k.String()), nil }
+func (r Rankine) MarshalText() ([]byte, error)    { return []byte(r.String()), nil }
+func (r Reaumur) MarshalText() ([]byte, error)    { return []byte(r.String()), nil }
+
+func (c *Celsius) UnmarshalText(b []byte) error    { return set(c, string(b)) }
+func (f *Fahrenheit) UnmarshalText(b []byte) error { return set(f, string(b)) }
+func (k *Kelvin) UnmarshalText(b []byte) error     { return set(k, string(b)) }
+func (r *Rankine) UnmarshalText(b []byte) error    { return set(r, string(b)) }
+func (r *Reaumur) UnmarshalText(b []byte) error    { return set(r, string(b)) }
+
+// The Value methods store a reading in a database column as text with its
+// unit, e.g. "21.5°C".
+func (c Celsius) Value() (driver.Value, error)    { return c.String(), nil }
+func (f Fahrenheit) Value() (driver.Value, error) { return f.String(), nil }
+func (k Kelvin) Value() (driver.Value, error)     { return k.String(), nil }
+func (r Rankine) Value() (driver.Value, error)    { return r.String(), nil }
+func (r Reaumur) Value() (driver.Value, error)    { return r.String(), nil }
+
+// The Scan methods read a column written by Value, or a numeric column
+// holding readings on the receiver's scale.
+func (c *Celsius) Scan(src any) error    { return scan(c, src) }
+func (f *Fahrenheit) Scan(src any) error { return scan(f, src) }
+func (k *Kelvin) Scan(src any) error     { return scan(k, src) }
+func (r *Rankine) Scan(src any) error    { return scan(r, src) }
+func (r *Reaumur) Scan(src any) error    { return scan(r, src) }
+
+func scan[T Temperature](p *T, src any) error {
+	switch src := src.(type) {
+	case string:
+		return set(p, src)
+	case []byte:
+		return set(p, string(src))
+	case float64:
+		return store(p, (*p).Scale().temperature(src))
+	case int64:
+		return store(p, (*p).Scale().temperature(float64(src)))
+	}
+	return fmt.Errorf("tempconv: cannot scan %T into %T", src, *p)
+}
@@ -0,0 +1,65 @@
+package tempconv
+
+import "flag"
+
+// The Set methods make pointers to the package's types satisfy flag.Value.
+// A flag accepts a reading on any scale, such as "20C" or "68°F", and stores
+// it converted to the flag's own scale. A bare number is taken to be on the
+// flag's scale already.
+func (c *Celsius) Set(s string) error    { return set(c, s) }
+func (f *Fahrenheit) Set(s string) error { return set(f, s) }
+func (k *Kelvin) Set(s string) error     { return set(k, s) }
+func (r *Rankine) Set(s string) error    { return set(r, s) }
+func (r *Reaumur) Set(s string) error    { return set(r, s) }
+
+func set[T Temperature](p *T, s string) error {
+	t, err := ParseIn(s, (*p).Scale())
+	if err != nil {
+		return err
+	}
+	return store(p, t)
+}
+
+// store converts t to the scale of *p and stores it there, failing if t is
+// below absolute zero.
+func store[T Temperature](p *T, t Temperature) error {
+	t, err := ConvertChecked(t, (*p).Scale())
+	if err != nil {
+		return err
+	}
+	*p = t.(T)
+	return nil
+}
+
+// CelsiusFlag defines a Celsius flag with the specified name, default
+// value, and usage, and returns the address of the flag variable.
+// The other XFlag functions do the same for their scales.
+func CelsiusFlag(name string, value Celsius, usage string) *Celsius {
+	return newFlag(name, value, usage)
+}
+
+func FahrenheitFlag(name string, value Fahrenheit, usage string) *Fahrenheit {
+	return newFlag(name, value, usage)
+}
+
+func KelvinFlag(name string, value Kelvin, usage string) *Kelvin {
+	return newFlag(name, value, usage)
+}
+
+func RankineFlag(name string, value Rankine, usage string) *Rankine {
+	return newFlag(name, value, usage)
+}
+
+func ReaumurFlag(name string, value Reaumur, usage string) *Reaumur {
+	return newFlag(name, value, usage)
+}
+
+func newFlag[T Temperature, P interface {
+	*T
+	flag.Value
+}](name string, value T, usage string) *T {
+	p := new(T)
+	*p = value
+	flag.Var(P(p), name, usage)
+	return p
+}
@@ -0,0 +1,193 @@
+package tempconv
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A UnitStyle selects how Format writes the unit of a reading.
+type UnitStyle int
+
+const (
+	SymbolUnit UnitStyle = iota // "21.5°C", as String writes it
+	ASCIIUnit                   // "21.5 degC"
+	NameUnit                    // "21.5 Celsius"
+)
+
+// unitNames holds the ASCIIUnit and NameUnit spellings of each scale.
+var unitNames = [...]struct{ symbol, ascii, name string }{
+	CelsiusScale:    {"°C", "degC", "Celsius"},
+	FahrenheitScale: {"°F", "degF", "Fahrenheit"},
+	KelvinScale:     {"K", "K", "kelvin"},
+	RankineScale:    {"°R", "degR", "Rankine"},
+	ReaumurScale:    {"°Ré", "degRe", "Réaumur"},
+}
+
+// A RoundingMode selects how Format drops digits beyond its precision.
+type RoundingMode int
+
+const (
+	RoundHalfEven   RoundingMode = iota // to nearest, ties to even
+	RoundHalfUp                         // to nearest, ties away from zero
+	RoundTowardZero                     // truncate
+	RoundDown                           // toward negative infinity
+	RoundUp                             // toward positive infinity
+)
+
+// FormatOptions controls how Format writes a reading.
+type FormatOptions struct {
+	// Precision is the number of digits after the decimal separator.
+	// A negative precision uses as few digits as needed, as String does.
+	Precision int
+	Rounding  RoundingMode
+	// TrimZeros drops trailing zeros after rounding, so that 100 is
+	// written "100" rather than "100.00".
+	TrimZeros bool
+	// DecimalSeparator replaces the "." in the number if it is not empty.
+	DecimalSeparator string
+	Unit             UnitStyle
+}
+
+// DefaultFormat formats a reading exactly as its String method does.
+var DefaultFormat = FormatOptions{Precision: -1}
+
+// commaLocales lists the languages that write a decimal comma.
+var commaLocales = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"id": true, "it": true, "nb": true, "nl": true, "pl": true, "pt": true,
+	"ru": true, "sv": true, "tr": true, "uk": true,
+}
+
+// LocaleFormat returns DefaultFormat with the decimal separator used by
+// the language of the BCP 47 tag, such as "de" or "fr-CA".
+func LocaleFormat(tag string) FormatOptions {
+	o := DefaultFormat
+	lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	if commaLocales[lang] {
+		o.DecimalSeparator = ","
+	}
+	return o
+}
+
+// Format writes t according to o, e.g. "36.7°C" for precision 1.
+func Format(t Temperature, o FormatOptions) string {
+	v, s := value(t)
+	num := formatNumber(v, o)
+	switch u := unitNames[s]; o.Unit {
+	case ASCIIUnit:
+		return num + " " + u.ascii
+	case NameUnit:
+		return num + " " + u.name
+	default:
+		return num + u.symbol
+	}
+}
+
+func formatNumber(v float64, o FormatOptions) string {
+	var num string
+	if o.Precision < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		num = strconv.FormatFloat(v, 'g', -1, 64)
+	} else {
+		num = round(v, o.Precision, o.Rounding)
+		if o.TrimZeros && strings.Contains(num, ".") {
+			num = strings.TrimRight(strings.TrimRight(num, "0"), ".")
+		}
+	}
+	if o.DecimalSeparator != "" {
+		num = strings.Replace(num, ".", o.DecimalSeparator, 1)
+	}
+	return num
+}
+
+// round writes v with prec digits after the point. It rounds the shortest
+// decimal that represents v, so that 2.675 rounds half up to 2.68 even
+// though the nearest float64 is slightly below it.
+func round(v float64, prec int, mode RoundingMode) string {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	neg := r.Sign() < 0
+	if rem.Sign() != 0 {
+		half := new(big.Int).Lsh(rem.Abs(rem), 1).Cmp(r.Denom())
+		var away bool
+		switch mode {
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundTowardZero:
+			away = false
+		case RoundDown:
+			away = neg
+		case RoundUp:
+			away = !neg
+		default:
+			away = half > 0 || half == 0 && q.Bit(0) == 1
+		}
+		if away && neg {
+			q.Sub(q, big.NewInt(1))
+		} else if away {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	digits := new(big.Int).Abs(q).String()
+	if len(digits) <= prec {
+		digits = strings.Repeat("0", prec-len(digits)+1) + digits
+	}
+	num := digits
+	if prec > 0 {
+		num = digits[:len(digits)-prec] + "." + digits[len(digits)-prec:]
+	}
+	if q.Sign() < 0 {
+		num = "-" + num
+	}
+	return num
+}
+
+// The Format methods make the package's types satisfy fmt.Formatter.
+// %v and %s write the reading with its unit and honor width and precision,
+// so %.1v writes "36.7°C"; %+v spells out the unit and %#v uses Go syntax.
+// Other verbs, such as %g or %.2f, format the bare number as before.
+func (c Celsius) Format(s fmt.State, verb rune)    { format(s, verb, c) }
+func (f Fahrenheit) Format(s fmt.State, verb rune) { format(s, verb, f) }
+func (k Kelvin) Format(s fmt.State, verb rune)     { format(s, verb, k) }
+func (r Rankine) Format(s fmt.State, verb rune)    { format(s, verb, r) }
+func (r Reaumur) Format(s fmt.State, verb rune)    { format(s, verb, r) }
+
+func format(s fmt.State, verb rune, t Temperature) {
+	v, _ := value(t)
+	switch verb {
+	case 'v', 's', 'q':
+		if verb == 'v' && s.Flag('#') {
+			fmt.Fprintf(s, "%T(%#v)", t, v)
+			return
+		}
+		o := DefaultFormat
+		if p, ok := s.Precision(); ok {
+			o.Precision = p
+		}
+		if s.Flag('+') {
+			o.Unit = NameUnit
+		}
+		str := Format(t, o)
+		if verb == 'q' {
+			str = strconv.Quote(str)
+		}
+		if w, ok := s.Width(); ok && w > utf8.RuneCountInString(str) {
+			fill := strings.Repeat(" ", w-utf8.RuneCountInString(str))
+			if s.Flag('-') {
+				str += fill
+			} else {
+				str = fill + str
+			}
+		}
+		io.WriteString(s, str)
+	default:
+		fmt.Fprintf(s, fmt.FormatString(s, verb), v)
+	}
+}
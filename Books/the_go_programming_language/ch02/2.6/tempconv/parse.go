@@ -0,0 +1,86 @@
+package tempconv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingUnit = errors.New("missing temperature unit")
+	ErrUnknownUnit = errors.New("unknown temperature unit")
+)
+
+// units maps every accepted unit spelling, in lower case, to its scale.
+// It includes the suffixes printed by the String methods and by Format so
+// that Parse round-trips their output.
+var units = map[string]Scale{
+	"c": CelsiusScale, "°c": CelsiusScale, "degc": CelsiusScale, "celsius": CelsiusScale,
+	"f": FahrenheitScale, "°f": FahrenheitScale, "degf": FahrenheitScale, "fahrenheit": FahrenheitScale,
+	"k": KelvinScale, "°k": KelvinScale, "kelvin": KelvinScale,
+	"r": RankineScale, "°r": RankineScale, "degr": RankineScale, "rankine": RankineScale,
+	"re": ReaumurScale, "°re": ReaumurScale, "ré": ReaumurScale, "°ré": ReaumurScale,
+	"degre": ReaumurScale, "reaumur": ReaumurScale, "réaumur": ReaumurScale,
+}
+
+// suffixes holds the keys of units, longest first, so that "°Ré" is not
+// mistaken for "é" following a Rankine reading.
+var suffixes = func() []string {
+	var s []string
+	for u := range units {
+		s = append(s, u)
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if len(s[i]) != len(s[j]) {
+			return len(s[i]) > len(s[j])
+		}
+		return s[i] < s[j]
+	})
+	return s
+}()
+
+// ParseScale parses a unit such as "C", "°F", "K" or "Rankine".
+func ParseScale(s string) (Scale, error) {
+	if sc, ok := units[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return sc, nil
+	}
+	return 0, fmt.Errorf("tempconv: %w %q", ErrUnknownUnit, s)
+}
+
+// Parse parses a reading with a unit suffix, such as "37.5C", "98.6°F" or
+// "310 K", and returns it as the package's type for that unit.
+func Parse(s string) (Temperature, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	var numErr error
+	for _, u := range suffixes {
+		if !strings.HasSuffix(lower, u) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(lower[:len(lower)-len(u)]), 64)
+		if err != nil {
+			if numErr == nil {
+				numErr = err.(*strconv.NumError).Err
+			}
+			continue
+		}
+		return units[u].temperature(v), nil
+	}
+	if numErr != nil {
+		return nil, fmt.Errorf("tempconv: parse %q: %w", s, numErr)
+	}
+	if _, err := strconv.ParseFloat(lower, 64); err == nil {
+		return nil, fmt.Errorf("tempconv: parse %q: %w", s, ErrMissingUnit)
+	}
+	return nil, fmt.Errorf("tempconv: parse %q: %w", s, ErrUnknownUnit)
+}
+
+// ParseIn is like Parse but takes a bare number, such as "20", to be a
+// reading on the scale def.
+func ParseIn(s string, def Scale) (Temperature, error) {
+	if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+		return def.temperature(v), nil
+	}
+	return Parse(s)
+}
@@ -0,0 +1,76 @@
+package tempconv
+
+import "strconv"
+
+// A Scale identifies one of the temperature scales known to the package.
+type Scale int
+
+const (
+	CelsiusScale Scale = iota
+	FahrenheitScale
+	KelvinScale
+	RankineScale
+	ReaumurScale
+)
+
+// scales describes every Scale by its size of degree and two fixed points,
+// so that any pair of scales converts with a single linear transform
+// instead of a hand-written function per pair.
+var scales = [...]struct {
+	name     string
+	num, den float64 // one degree on this scale is num/den degrees Celsius
+	freezing float64 // freezing point of water on this scale
+	zero     float64 // absolute zero on this scale
+}{
+	CelsiusScale:    {"C", 1, 1, 0, -273.15},
+	FahrenheitScale: {"F", 5, 9, 32, -459.67},
+	KelvinScale:     {"K", 1, 1, 273.15, 0},
+	RankineScale:    {"R", 5, 9, 491.67, 0},
+	ReaumurScale:    {"Re", 5, 4, 0, -218.52},
+}
+
+// Scales lists every supported scale.
+var Scales = []Scale{CelsiusScale, FahrenheitScale, KelvinScale, RankineScale, ReaumurScale}
+
+func (s Scale) valid() bool { return s >= 0 && int(s) < len(scales) }
+
+func (s Scale) String() string {
+	if !s.valid() {
+		return "Scale(" + strconv.Itoa(int(s)) + ")"
+	}
+	return scales[s].name
+}
+
+// temperature returns v as a Temperature of the package's type for s.
+func (s Scale) temperature(v float64) Temperature {
+	switch s {
+	case CelsiusScale:
+		return Celsius(v)
+	case FahrenheitScale:
+		return Fahrenheit(v)
+	case KelvinScale:
+		return Kelvin(v)
+	case RankineScale:
+		return Rankine(v)
+	case ReaumurScale:
+		return Reaumur(v)
+	}
+	panic("tempconv: unknown scale " + s.String())
+}
+
+// ConvertValue converts the reading v from one scale to another.
+// It panics if either scale is unknown.
+func ConvertValue(v float64, from, to Scale) float64 {
+	if from == to {
+		return v
+	}
+	f, t := scales[from], scales[to]
+	// Anchor on absolute zero between two absolute scales and on the
+	// freezing point otherwise, which keeps the common conversions such
+	// as 100°C = 212°F and 100K = 180°R exact.
+	f0, t0 := f.freezing, t.freezing
+	if f.zero == 0 && t.zero == 0 {
+		f0, t0 = 0, 0
+	}
+	return (v-f0)*(f.num*t.den)/(f.den*t.num) + t0
+}
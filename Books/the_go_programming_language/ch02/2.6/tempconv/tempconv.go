@@ -2,10 +2,20 @@ package tempconv
 
 import "fmt"
 
+// A Temperature is a reading on one of the package's scales. It lets code
+// handle readings without knowing their unit.
+type Temperature interface {
+	ToKelvin() Kelvin
+	Scale() Scale
+	String() string
+}
+
 type (
 	Celsius    float64
 	Fahrenheit float64
 	Kelvin     float64
+	Rankine    float64
+	Reaumur    float64
 )
 
 const (
@@ -20,8 +30,30 @@ const (
 	FreezingF     Fahrenheit = 32
 	BoilingF      Fahrenheit = 212
 	AbsoluteZeroF Fahrenheit = -459.67
+
+	AbsoluteZeroR Rankine = 0
+	FreezingR     Rankine = 491.67
+	BoilingR      Rankine = 671.67
+
+	AbsoluteZeroRe Reaumur = -218.52
+	FreezingRe     Reaumur = 0
+	BoilingRe      Reaumur = 80
 )
 
 func (c Celsius) String() string    { return fmt.Sprintf("%g°C", c) }
 func (f Fahrenheit) String() string { return fmt.Sprintf("%g°F", f) }
 func (k Kelvin) String() string     { return fmt.Sprintf("%gK", k) }
+func (r Rankine) String() string    { return fmt.Sprintf("%g°R", r) }
+func (r Reaumur) String() string    { return fmt.Sprintf("%g°Ré", r) }
+
+func (c Celsius) Scale() Scale    { return CelsiusScale }
+func (f Fahrenheit) Scale() Scale { return FahrenheitScale }
+func (k Kelvin) Scale() Scale     { return KelvinScale }
+func (r Rankine) Scale() Scale    { return RankineScale }
+func (r Reaumur) Scale() Scale    { return ReaumurScale }
+
+func (c Celsius) ToKelvin() Kelvin    { return Convert(c, KelvinScale).(Kelvin) }
+func (f Fahrenheit) ToKelvin() Kelvin { return Convert(f, KelvinScale).(Kelvin) }
+func (k Kelvin) ToKelvin() Kelvin     { return k }
+func (r Rankine) ToKelvin() Kelvin    { return Convert(r, KelvinScale).(Kelvin) }
+func (r Reaumur) ToKelvin() Kelvin    { return Convert(r, KelvinScale).(Kelvin) }
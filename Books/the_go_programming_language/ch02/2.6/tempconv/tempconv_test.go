@@ -0,0 +1,150 @@
+package tempconv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+var readings = []Temperature{
+	Celsius(37.5),
+	Celsius(36.666666666666664),
+	Celsius(-273.15),
+	Celsius(0),
+	Fahrenheit(98.6),
+	Fahrenheit(-459.67),
+	Fahrenheit(1e21),
+	Kelvin(310),
+	Kelvin(1e-9),
+	Rankine(491.67),
+	Reaumur(-12.25),
+}
+
+// Parse must read back what String writes, to the bit and the type.
+func TestParseString(t *testing.T) {
+	for _, want := range readings {
+		got, err := Parse(want.String())
+		if err != nil {
+			t.Errorf("Parse(%q): %v", want.String(), err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Parse(%q) = %#v, want %#v", want.String(), got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Temperature
+		err  error
+	}{
+		{"37.5C", Celsius(37.5), nil},
+		{"98.6°F", Fahrenheit(98.6), nil},
+		{"310 K", Kelvin(310), nil},
+		{" -40 fahrenheit ", Fahrenheit(-40), nil},
+		{"12°Ré", Reaumur(12), nil},
+		{"12re", Reaumur(12), nil},
+		{"491.67 degR", Rankine(491.67), nil},
+		{"20", nil, ErrMissingUnit},
+		{"20 X", nil, ErrUnknownUnit},
+		{"C", nil, strconv.ErrSyntax},
+		{"1e400C", nil, strconv.ErrRange},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.in)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("Parse(%q) = %v, %v; want %v, %v", test.in, got, err, test.want, test.err)
+		}
+	}
+}
+
+// Format with DefaultFormat must write what String does, in every unit
+// style Parse reads back.
+func TestFormatParse(t *testing.T) {
+	for _, want := range readings {
+		if s := Format(want, DefaultFormat); s != want.String() {
+			t.Errorf("Format(%#v, DefaultFormat) = %q, want %q", want, s, want.String())
+		}
+		for _, unit := range []UnitStyle{SymbolUnit, ASCIIUnit, NameUnit} {
+			s := Format(want, FormatOptions{Precision: -1, Unit: unit})
+			if got, err := Parse(s); err != nil || got != want {
+				t.Errorf("Parse(%q) = %#v, %v; want %#v", s, got, err, want)
+			}
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		t    Temperature
+		o    FormatOptions
+		want string
+	}{
+		{Celsius(36.666666666666664), FormatOptions{Precision: 1}, "36.7°C"},
+		{Celsius(100), FormatOptions{Precision: 2}, "100.00°C"},
+		{Celsius(100), FormatOptions{Precision: 2, TrimZeros: true}, "100°C"},
+		{Celsius(21.5), FormatOptions{Precision: 2, TrimZeros: true}, "21.5°C"},
+		{Celsius(21.5), FormatOptions{Precision: 1, Unit: ASCIIUnit}, "21.5 degC"},
+		{Celsius(21.5), FormatOptions{Precision: 1, Unit: NameUnit}, "21.5 Celsius"},
+		{Kelvin(300), FormatOptions{Precision: 0, Unit: NameUnit}, "300 kelvin"},
+		{Reaumur(8), FormatOptions{Precision: 0, Unit: ASCIIUnit}, "8 degRe"},
+		{Celsius(21.55), FormatOptions{Precision: 1, DecimalSeparator: ","}, "21,6°C"},
+		{Celsius(21.55), LocaleFormat("de-DE"), "21,55°C"},
+		{Celsius(21.55), LocaleFormat("en-US"), "21.55°C"},
+
+		// Ties go to the even digit, or away from zero with RoundHalfUp.
+		{Celsius(2.5), FormatOptions{Precision: 0}, "2°C"},
+		{Celsius(3.5), FormatOptions{Precision: 0}, "4°C"},
+		{Celsius(-2.5), FormatOptions{Precision: 0}, "-2°C"},
+		{Celsius(2.5), FormatOptions{Precision: 0, Rounding: RoundHalfUp}, "3°C"},
+		{Celsius(-2.5), FormatOptions{Precision: 0, Rounding: RoundHalfUp}, "-3°C"},
+		// 2.675 is stored a little below itself but is rounded as written.
+		{Celsius(2.675), FormatOptions{Precision: 2, Rounding: RoundHalfUp}, "2.68°C"},
+		{Celsius(2.675), FormatOptions{Precision: 2}, "2.68°C"},
+		{Celsius(2.665), FormatOptions{Precision: 2}, "2.66°C"},
+
+		{Celsius(-1.21), FormatOptions{Precision: 1, Rounding: RoundTowardZero}, "-1.2°C"},
+		{Celsius(-1.21), FormatOptions{Precision: 1, Rounding: RoundDown}, "-1.3°C"},
+		{Celsius(1.29), FormatOptions{Precision: 1, Rounding: RoundDown}, "1.2°C"},
+		{Celsius(-1.29), FormatOptions{Precision: 1, Rounding: RoundUp}, "-1.2°C"},
+		{Celsius(1.21), FormatOptions{Precision: 1, Rounding: RoundUp}, "1.3°C"},
+
+		// Rounding to zero drops the sign.
+		{Celsius(-0.04), FormatOptions{Precision: 1}, "0.0°C"},
+		{Celsius(-0.04), FormatOptions{Precision: 1, Rounding: RoundUp}, "0.0°C"},
+		{Celsius(-0.04), FormatOptions{Precision: 1, Rounding: RoundDown}, "-0.1°C"},
+		{Celsius(-0.4), FormatOptions{Precision: 0, TrimZeros: true}, "0°C"},
+		{Celsius(0.004), FormatOptions{Precision: 2}, "0.00°C"},
+		{Celsius(0.05), FormatOptions{Precision: 3}, "0.050°C"},
+	}
+	for _, test := range tests {
+		if got := Format(test.t, test.o); got != test.want {
+			t.Errorf("Format(%#v, %+v) = %q, want %q", test.t, test.o, got, test.want)
+		}
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	c := Celsius(36.666666666666664)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "36.666666666666664°C"},
+		{"%.1v", "36.7°C"},
+		{"%+.1v", "36.7 Celsius"},
+		{"%8.1v", "  36.7°C"},
+		{"%-8.1v|", "36.7°C  |"},
+		{"%q", `"36.666666666666664°C"`},
+		{"%#v", "tempconv.Celsius(36.666666666666664)"},
+		{"%.2f", "36.67"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, c); got != test.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", test.format, got, test.want)
+		}
+	}
+}
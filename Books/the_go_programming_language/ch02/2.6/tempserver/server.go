@@ -0,0 +1,158 @@
+// Tempserver serves tempconv conversions over HTTP.
+//
+//	GET  /convert?value=100&from=C&to=F
+//	POST /batch  [{"value":100,"from":"C","to":"F"}, {"value":"98.6°F","to":"K"}]
+//
+// Conversions are answered as {"from":{"value":100,"unit":"C"},"to":{...}}
+// and failures as {"error":{"code":"unknown_unit","message":"..."}}.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"2.6/tempconv"
+)
+
+var addr = flag.String("addr", "localhost:8000", "address to listen on")
+
+func main() {
+	flag.Parse()
+	http.HandleFunc("/convert", convertHandler)
+	http.HandleFunc("/batch", batchHandler)
+	log.Printf("tempserver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// A request asks for one conversion. Value is a number on the From scale
+// or a string with its own unit, such as "98.6°F".
+type request struct {
+	Value any    `json:"value"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to"`
+}
+
+// A result is the answer to one request: a conversion or an error.
+type result struct {
+	From  tempconv.Temperature `json:"from,omitempty"`
+	To    tempconv.Temperature `json:"to,omitempty"`
+	Error *apiError            `json:"error,omitempty"`
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	status  int
+}
+
+// convertHandler answers GET /convert?value=100&from=C&to=F.
+func convertHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &apiError{"method_not_allowed", "use GET", http.StatusMethodNotAllowed})
+		return
+	}
+	res := convert(request{r.FormValue("value"), r.FormValue("from"), r.FormValue("to")})
+	if res.Error != nil {
+		writeError(w, res.Error)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// batchHandler answers POST /batch with a JSON array of requests. Each
+// element gets its own result, so one bad reading does not fail the rest.
+func batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, &apiError{"method_not_allowed", "use POST", http.StatusMethodNotAllowed})
+		return
+	}
+	var reqs []request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&reqs); err != nil {
+		writeError(w, &apiError{"bad_request", "body must be a JSON array of conversions: " + err.Error(), http.StatusBadRequest})
+		return
+	}
+	results := make([]result, len(reqs))
+	for i, req := range reqs {
+		results[i] = convert(req)
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func convert(req request) result {
+	if req.To == "" {
+		return result{Error: &apiError{"missing_parameter", `"to" is required`, http.StatusBadRequest}}
+	}
+	to, err := tempconv.ParseScale(req.To)
+	if err != nil {
+		return result{Error: toAPIError(err)}
+	}
+	var t tempconv.Temperature
+	switch v := req.Value.(type) {
+	case string:
+		if v == "" {
+			return result{Error: &apiError{"missing_parameter", `"value" is required`, http.StatusBadRequest}}
+		}
+		t, err = parse(v, req.From)
+	case float64:
+		t, err = parse(fmt.Sprint(v), req.From)
+	default:
+		err = fmt.Errorf("value must be a number or a string, not %v", v)
+	}
+	if err != nil {
+		return result{Error: toAPIError(err)}
+	}
+	c, err := tempconv.ConvertChecked(t, to)
+	if err != nil {
+		return result{Error: toAPIError(err)}
+	}
+	return result{From: t, To: c}
+}
+
+// parse parses a value that may carry its own unit. A bare number needs
+// the from scale.
+func parse(value, from string) (tempconv.Temperature, error) {
+	if from == "" {
+		return tempconv.Parse(value)
+	}
+	s, err := tempconv.ParseScale(from)
+	if err != nil {
+		return nil, err
+	}
+	return tempconv.ParseIn(value, s)
+}
+
+// toAPIError maps a tempconv error to its code and HTTP status.
+func toAPIError(err error) *apiError {
+	var below *tempconv.ErrBelowAbsoluteZero
+	switch {
+	case errors.As(err, &below):
+		return &apiError{"below_absolute_zero", err.Error(), http.StatusUnprocessableEntity}
+	case errors.Is(err, tempconv.ErrUnknownUnit):
+		return &apiError{"unknown_unit", err.Error(), http.StatusBadRequest}
+	case errors.Is(err, tempconv.ErrMissingUnit):
+		return &apiError{"missing_unit", err.Error() + `; give a unit or "from"`, http.StatusBadRequest}
+	}
+	return &apiError{"invalid_value", err.Error(), http.StatusBadRequest}
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	writeJSON(w, e.status, result{Error: e})
+}
+
+// writeJSON encodes v before writing the header, so that a value JSON
+// cannot hold becomes a 500 rather than an empty 200.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Print(err)
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(result{Error: &apiError{"internal_error", "the response could not be encoded", status}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
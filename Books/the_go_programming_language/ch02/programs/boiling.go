@@ -1,4 +1,5 @@
 // Boiling prints the boiling point of water.
+// ../2.6/boiling prints it through tempconv.Format.
 
 package main					// package-level declaration
 